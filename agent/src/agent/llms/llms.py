@@ -19,6 +19,11 @@ llms = {
     "deepseek": get_deepseek_llm()
 }
 
+# 各模型的上下文窗口（token），注册到后端时上报
+max_context_tokens = {
+    "deepseek": app_config.get("max_context_tokens", 64000),
+}
+
 
 def get_llm(model_name: str):
     return llms.get(model_name)
\ No newline at end of file
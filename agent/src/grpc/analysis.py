@@ -0,0 +1,110 @@
+import json
+import logging
+
+import grpc
+from langchain_core.messages import HumanMessage, SystemMessage
+
+import protopath  # noqa: F401
+from analyzer.v1 import analyzer_pb2 as pb2
+from analyzer.v1 import analyzer_pb2_grpc as pb2_grpc
+from usage import UsageTracker
+
+# 流式分析从日志末尾拉取的字节数，构建失败的原因通常出现在日志末尾
+LOG_TAIL_BYTES = 64 * 1024
+
+DEFAULT_PROMPT = "你是 CI 构建失败分析助手。根据任务信息和日志，找出构建失败的根因并给出修复建议。"
+OUTPUT_FORMAT = (
+    "只输出一个 JSON 对象，字段为 summary（一句话概括）、root_cause（根因）、suggestion（修复建议）、"
+    "category（compile、test、infra、dependency 或 other）。"
+)
+
+logger = logging.getLogger(__name__)
+
+
+class Analyzer(pb2_grpc.AnalyzerServicer):
+    """构建失败分析：把任务信息和日志交给模型，解析模型返回的 JSON。get_llm 按模型名返回 LangChain 模型，
+    不支持的模型返回 None。流式分析只拉取日志末尾 LOG_TAIL_BYTES 字节；分析失败时先报告已消耗的用量再结束流"""
+
+    def __init__(self, get_llm):
+        self._get_llm = get_llm
+
+    def Analyze(self, request, context):
+        llm = self._llm(request.model, context)
+        tracker = UsageTracker(request.model)
+        try:
+            reply = analyze(llm, request.job, request.prompt.text, request.log, tracker)
+        except Exception:
+            logger.exception("analysis %d failed", request.analysis_id)
+            context.abort(grpc.StatusCode.INTERNAL, "analysis failed")
+        reply.usage.CopyFrom(tracker.to_proto())
+        return reply
+
+    def AnalyzeStream(self, request_iterator, context):
+        first = next(request_iterator, None)
+        if first is None or first.WhichOneof("payload") != "start":
+            context.abort(grpc.StatusCode.INVALID_ARGUMENT, "the first message must be AnalyzeStart")
+        start = first.start
+        llm = self._llm(start.model, context)
+
+        log = b""
+        if start.log_size > 0:
+            offset = max(0, start.log_size - LOG_TAIL_BYTES)
+            yield pb2.AnalyzeStreamReply(log_request=pb2.LogRequest(offset=offset, length=start.log_size - offset))
+            log = _receive_range(request_iterator, context)
+
+        tracker = UsageTracker(start.model)
+        try:
+            reply = analyze(llm, start.job, start.prompt.text, log.decode("utf-8", "replace"), tracker)
+        except Exception:
+            logger.exception("analysis %d failed", start.analysis_id)
+            # 模型调用已产生费用，后端按最后收到的用量记录
+            yield pb2.AnalyzeStreamReply(usage=tracker.to_proto())
+            context.abort(grpc.StatusCode.INTERNAL, "analysis failed")
+        reply.usage.CopyFrom(tracker.to_proto())
+        yield pb2.AnalyzeStreamReply(result=reply)
+
+    def _llm(self, model, context):
+        llm = self._get_llm(model)
+        if llm is None:
+            context.abort(grpc.StatusCode.INVALID_ARGUMENT, f"unsupported model {model!r}")
+        return llm
+
+
+def _receive_range(request_iterator, context):
+    """读取一次 LogRequest 对应的全部分片"""
+    data = bytearray()
+    for msg in request_iterator:
+        data += msg.chunk.data
+        if msg.chunk.last:
+            return bytes(data)
+    context.abort(grpc.StatusCode.CANCELLED, "stream closed before the log range was sent")
+
+
+def analyze(llm, job, prompt, log, tracker):
+    """调用模型分析一次失败，prompt 为后端渲染的提示词，为空时使用 DEFAULT_PROMPT"""
+    messages = [
+        SystemMessage(content=f"{prompt or DEFAULT_PROMPT}\n\n{OUTPUT_FORMAT}"),
+        HumanMessage(content=(
+            f"项目：{job.project}\n任务：{job.name}\n分支：{job.branch}\n提交：{job.commit_sha}\n"
+            f"状态：{job.status}\n\n日志：\n{log}"
+        )),
+    ]
+    response = llm.invoke(messages, config={"callbacks": [tracker]})
+    return parse_reply(response.content)
+
+
+def parse_reply(text):
+    """解析模型输出中的 JSON 对象，允许前后带有说明或代码块标记；无法解析时整段输出作为摘要"""
+    start, end = text.find("{"), text.rfind("}")
+    try:
+        data = json.loads(text[start:end + 1]) if 0 <= start < end else None
+    except ValueError:
+        data = None
+    if not isinstance(data, dict):
+        return pb2.AnalyzeReply(summary=text.strip())
+    return pb2.AnalyzeReply(
+        summary=str(data.get("summary", "")),
+        root_cause=str(data.get("root_cause", "")),
+        suggestion=str(data.get("suggestion", "")),
+        category=str(data.get("category", "")),
+    )
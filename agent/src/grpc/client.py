@@ -1,4 +1,5 @@
 import grpc
+import protopath  # noqa: F401
 import hello_pb2_grpc as pb2_grpc
 import hello_pb2 as pb2
 
@@ -0,0 +1,57 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# NO CHECKED-IN PROTOBUF GENCODE
+# source: analyzer/v1/analyzer.proto
+# Protobuf Python Version: 6.31.1
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(
+    _runtime_version.Domain.PUBLIC,
+    6,
+    31,
+    1,
+    '',
+    'analyzer/v1/analyzer.proto'
+)
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+
+
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1a\x61nalyzer/v1/analyzer.proto\x12\x0f\x63iv.analyzer.v1\"\x8d\x01\n\x03Job\x12\n\n\x02id\x18\x01 \x01(\x03\x12\x0f\n\x07project\x18\x02 \x01(\t\x12\x0c\n\x04name\x18\x03 \x01(\t\x12\x0e\n\x06\x62ranch\x18\x04 \x01(\t\x12\x12\n\ncommit_sha\x18\x05 \x01(\t\x12\x0e\n\x06status\x18\x06 \x01(\t\x12\x12\n\nstarted_at\x18\x07 \x01(\x03\x12\x13\n\x0b\x66inished_at\x18\x08 \x01(\x03\"\xa1\x01\n\x0e\x41nalyzeRequest\x12\x13\n\x0b\x61nalysis_id\x18\x01 \x01(\x03\x12!\n\x03job\x18\x02 \x01(\x0b\x32\x14.civ.analyzer.v1.Job\x12\x0b\n\x03log\x18\x03 \x01(\t\x12\r\n\x05model\x18\x04 \x01(\t\x12\x12\n\ntool_token\x18\x05 \x01(\t\x12\'\n\x06prompt\x18\x06 \x01(\x0b\x32\x17.civ.analyzer.v1.Prompt\"\x80\x01\n\x0c\x41nalyzeReply\x12\x0f\n\x07summary\x18\x01 \x01(\t\x12\x12\n\nroot_cause\x18\x02 \x01(\t\x12\x12\n\nsuggestion\x18\x03 \x01(\t\x12\x10\n\x08\x63\x61tegory\x18\x04 \x01(\t\x12%\n\x05usage\x18\x05 \x01(\x0b\x32\x16.civ.analyzer.v1.Usage\"\\\n\x05Usage\x12\r\n\x05model\x18\x01 \x01(\t\x12\x15\n\rprompt_tokens\x18\x02 \x01(\x03\x12\x19\n\x11\x63ompletion_tokens\x18\x03 \x01(\x03\x12\x12\n\nlatency_ms\x18\x04 \x01(\x03\"}\n\x14\x41nalyzeStreamRequest\x12.\n\x05start\x18\x01 \x01(\x0b\x32\x1d.civ.analyzer.v1.AnalyzeStartH\x00\x12*\n\x05\x63hunk\x18\x02 \x01(\x0b\x32\x19.civ.analyzer.v1.LogChunkH\x00\x42\t\n\x07payload\"\xa4\x01\n\x0c\x41nalyzeStart\x12\x13\n\x0b\x61nalysis_id\x18\x01 \x01(\x03\x12!\n\x03job\x18\x02 \x01(\x0b\x32\x14.civ.analyzer.v1.Job\x12\r\n\x05model\x18\x03 \x01(\t\x12\x10\n\x08log_size\x18\x04 \x01(\x03\x12\x12\n\ntool_token\x18\x05 \x01(\t\x12\'\n\x06prompt\x18\x06 \x01(\x0b\x32\x17.civ.analyzer.v1.Prompt\"5\n\x06Prompt\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0f\n\x07version\x18\x02 \x01(\x05\x12\x0c\n\x04text\x18\x03 \x01(\t\"6\n\x08LogChunk\x12\x0e\n\x06offset\x18\x01 \x01(\x03\x12\x0c\n\x04\x64\x61ta\x18\x02 \x01(\x0c\x12\x0c\n\x04last\x18\x03 \x01(\x08\"\xad\x01\n\x12\x41nalyzeStreamReply\x12\x32\n\x0blog_request\x18\x01 \x01(\x0b\x32\x1b.civ.analyzer.v1.LogRequestH\x00\x12/\n\x06result\x18\x02 \x01(\x0b\x32\x1d.civ.analyzer.v1.AnalyzeReplyH\x00\x12\'\n\x05usage\x18\x03 \x01(\x0b\x32\x16.civ.analyzer.v1.UsageH\x00\x42\t\n\x07payload\",\n\nLogRequest\x12\x0e\n\x06offset\x18\x01 \x01(\x03\x12\x0e\n\x06length\x18\x02 \x01(\x03\x32\xba\x01\n\x08\x41nalyzer\x12K\n\x07\x41nalyze\x12\x1f.civ.analyzer.v1.AnalyzeRequest\x1a\x1d.civ.analyzer.v1.AnalyzeReply\"\x00\x12\x61\n\rAnalyzeStream\x12%.civ.analyzer.v1.AnalyzeStreamRequest\x1a#.civ.analyzer.v1.AnalyzeStreamReply\"\x00(\x01\x30\x01\x42\"Z civ/proto/analyzer/v1;analyzerv1b\x06proto3')
+
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'analyzer.v1.analyzer_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+  _globals['DESCRIPTOR']._loaded_options = None
+  _globals['DESCRIPTOR']._serialized_options = b'Z civ/proto/analyzer/v1;analyzerv1'
+  _globals['_JOB']._serialized_start=48
+  _globals['_JOB']._serialized_end=189
+  _globals['_ANALYZEREQUEST']._serialized_start=192
+  _globals['_ANALYZEREQUEST']._serialized_end=353
+  _globals['_ANALYZEREPLY']._serialized_start=356
+  _globals['_ANALYZEREPLY']._serialized_end=484
+  _globals['_USAGE']._serialized_start=486
+  _globals['_USAGE']._serialized_end=578
+  _globals['_ANALYZESTREAMREQUEST']._serialized_start=580
+  _globals['_ANALYZESTREAMREQUEST']._serialized_end=705
+  _globals['_ANALYZESTART']._serialized_start=708
+  _globals['_ANALYZESTART']._serialized_end=872
+  _globals['_PROMPT']._serialized_start=874
+  _globals['_PROMPT']._serialized_end=927
+  _globals['_LOGCHUNK']._serialized_start=929
+  _globals['_LOGCHUNK']._serialized_end=983
+  _globals['_ANALYZESTREAMREPLY']._serialized_start=986
+  _globals['_ANALYZESTREAMREPLY']._serialized_end=1159
+  _globals['_LOGREQUEST']._serialized_start=1161
+  _globals['_LOGREQUEST']._serialized_end=1205
+  _globals['_ANALYZER']._serialized_start=1208
+  _globals['_ANALYZER']._serialized_end=1394
+# @@protoc_insertion_point(module_scope)
@@ -0,0 +1,49 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# NO CHECKED-IN PROTOBUF GENCODE
+# source: analyzer/v1/registry.proto
+# Protobuf Python Version: 6.31.1
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(
+    _runtime_version.Domain.PUBLIC,
+    6,
+    31,
+    1,
+    '',
+    'analyzer/v1/registry.proto'
+)
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+
+
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x1a\x61nalyzer/v1/registry.proto\x12\x0f\x63iv.analyzer.v1\"5\n\tModelInfo\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x1a\n\x12max_context_tokens\x18\x02 \x01(\x05\"\x8c\x01\n\tAgentInfo\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0f\n\x07\x61\x64\x64ress\x18\x02 \x01(\t\x12\x0f\n\x07version\x18\x03 \x01(\t\x12*\n\x06models\x18\x04 \x03(\x0b\x32\x1a.civ.analyzer.v1.ModelInfo\x12\x16\n\x0e\x61nalysis_types\x18\x05 \x03(\t\x12\r\n\x05tools\x18\x06 \x03(\t\"<\n\x0fRegisterRequest\x12)\n\x05\x61gent\x18\x01 \x01(\x0b\x32\x1a.civ.analyzer.v1.AgentInfo\"3\n\rRegisterReply\x12\"\n\x1aheartbeat_interval_seconds\x18\x01 \x01(\x05\"\x1f\n\x11\x44\x65registerRequest\x12\n\n\x02id\x18\x01 \x01(\t\"\x11\n\x0f\x44\x65registerReply2\xb0\x01\n\x08Registry\x12N\n\x08Register\x12 .civ.analyzer.v1.RegisterRequest\x1a\x1e.civ.analyzer.v1.RegisterReply\"\x00\x12T\n\nDeregister\x12\".civ.analyzer.v1.DeregisterRequest\x1a .civ.analyzer.v1.DeregisterReply\"\x00\x42\"Z civ/proto/analyzer/v1;analyzerv1b\x06proto3')
+
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'analyzer.v1.registry_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+  _globals['DESCRIPTOR']._loaded_options = None
+  _globals['DESCRIPTOR']._serialized_options = b'Z civ/proto/analyzer/v1;analyzerv1'
+  _globals['_MODELINFO']._serialized_start=47
+  _globals['_MODELINFO']._serialized_end=100
+  _globals['_AGENTINFO']._serialized_start=103
+  _globals['_AGENTINFO']._serialized_end=243
+  _globals['_REGISTERREQUEST']._serialized_start=245
+  _globals['_REGISTERREQUEST']._serialized_end=305
+  _globals['_REGISTERREPLY']._serialized_start=307
+  _globals['_REGISTERREPLY']._serialized_end=358
+  _globals['_DEREGISTERREQUEST']._serialized_start=360
+  _globals['_DEREGISTERREQUEST']._serialized_end=391
+  _globals['_DEREGISTERREPLY']._serialized_start=393
+  _globals['_DEREGISTERREPLY']._serialized_end=410
+  _globals['_REGISTRY']._serialized_start=413
+  _globals['_REGISTRY']._serialized_end=589
+# @@protoc_insertion_point(module_scope)
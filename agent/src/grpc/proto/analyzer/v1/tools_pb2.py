@@ -0,0 +1,58 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# NO CHECKED-IN PROTOBUF GENCODE
+# source: analyzer/v1/tools.proto
+# Protobuf Python Version: 6.31.1
+"""Generated protocol buffer code."""
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import runtime_version as _runtime_version
+from google.protobuf import symbol_database as _symbol_database
+from google.protobuf.internal import builder as _builder
+_runtime_version.ValidateProtobufRuntimeVersion(
+    _runtime_version.Domain.PUBLIC,
+    6,
+    31,
+    1,
+    '',
+    'analyzer/v1/tools.proto'
+)
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+from analyzer.v1 import analyzer_pb2 as analyzer_dot_v1_dot_analyzer__pb2
+
+
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x17\x61nalyzer/v1/tools.proto\x12\x0f\x63iv.analyzer.v1\x1a\x1a\x61nalyzer/v1/analyzer.proto\"\x1f\n\rGetJobRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\x03\"D\n\x15ListRecentRunsRequest\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0e\n\x06\x62ranch\x18\x02 \x01(\t\x12\r\n\x05limit\x18\x03 \x01(\x05\"9\n\x13ListRecentRunsReply\x12\"\n\x04runs\x18\x01 \x03(\x0b\x32\x14.civ.analyzer.v1.Job\"D\n\x12GetLogRangeRequest\x12\x0e\n\x06job_id\x18\x01 \x01(\x03\x12\x0e\n\x06offset\x18\x02 \x01(\x03\x12\x0e\n\x06length\x18\x03 \x01(\x03\"B\n\x10GetLogRangeReply\x12\x0e\n\x06offset\x18\x01 \x01(\x03\x12\x0c\n\x04\x64\x61ta\x18\x02 \x01(\x0c\x12\x10\n\x08log_size\x18\x03 \x01(\x03\"=\n\x14GetCommitDiffRequest\x12\x12\n\ncommit_sha\x18\x01 \x01(\t\x12\x11\n\tmax_bytes\x18\x02 \x01(\x05\"I\n\x12GetCommitDiffReply\x12\x12\n\ncommit_sha\x18\x01 \x01(\t\x12\x0c\n\x04\x64iff\x18\x02 \x01(\t\x12\x11\n\ttruncated\x18\x03 \x01(\x08\"N\n\x1cSearchSimilarFailuresRequest\x12\r\n\x05query\x18\x01 \x01(\t\x12\x10\n\x08\x63\x61tegory\x18\x02 \x01(\t\x12\r\n\x05limit\x18\x03 \x01(\x05\"\x93\x01\n\x0eSimilarFailure\x12!\n\x03job\x18\x01 \x01(\x0b\x32\x14.civ.analyzer.v1.Job\x12\x13\n\x0b\x61nalysis_id\x18\x02 \x01(\x03\x12\x0f\n\x07summary\x18\x03 \x01(\t\x12\x12\n\nroot_cause\x18\x04 \x01(\t\x12\x12\n\nsuggestion\x18\x05 \x01(\t\x12\x10\n\x08\x63\x61tegory\x18\x06 \x01(\t\"O\n\x1aSearchSimilarFailuresReply\x12\x31\n\x08\x66\x61ilures\x18\x01 \x03(\x0b\x32\x1f.civ.analyzer.v1.SimilarFailure2\xda\x03\n\x05Tools\x12@\n\x06GetJob\x12\x1e.civ.analyzer.v1.GetJobRequest\x1a\x14.civ.analyzer.v1.Job\"\x00\x12`\n\x0eListRecentRuns\x12&.civ.analyzer.v1.ListRecentRunsRequest\x1a$.civ.analyzer.v1.ListRecentRunsReply\"\x00\x12W\n\x0bGetLogRange\x12#.civ.analyzer.v1.GetLogRangeRequest\x1a!.civ.analyzer.v1.GetLogRangeReply\"\x00\x12]\n\rGetCommitDiff\x12%.civ.analyzer.v1.GetCommitDiffRequest\x1a#.civ.analyzer.v1.GetCommitDiffReply\"\x00\x12u\n\x15SearchSimilarFailures\x12-.civ.analyzer.v1.SearchSimilarFailuresRequest\x1a+.civ.analyzer.v1.SearchSimilarFailuresReply\"\x00\x42\"Z civ/proto/analyzer/v1;analyzerv1b\x06proto3')
+
+_globals = globals()
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'analyzer.v1.tools_pb2', _globals)
+if not _descriptor._USE_C_DESCRIPTORS:
+  _globals['DESCRIPTOR']._loaded_options = None
+  _globals['DESCRIPTOR']._serialized_options = b'Z civ/proto/analyzer/v1;analyzerv1'
+  _globals['_GETJOBREQUEST']._serialized_start=72
+  _globals['_GETJOBREQUEST']._serialized_end=103
+  _globals['_LISTRECENTRUNSREQUEST']._serialized_start=105
+  _globals['_LISTRECENTRUNSREQUEST']._serialized_end=173
+  _globals['_LISTRECENTRUNSREPLY']._serialized_start=175
+  _globals['_LISTRECENTRUNSREPLY']._serialized_end=232
+  _globals['_GETLOGRANGEREQUEST']._serialized_start=234
+  _globals['_GETLOGRANGEREQUEST']._serialized_end=302
+  _globals['_GETLOGRANGEREPLY']._serialized_start=304
+  _globals['_GETLOGRANGEREPLY']._serialized_end=370
+  _globals['_GETCOMMITDIFFREQUEST']._serialized_start=372
+  _globals['_GETCOMMITDIFFREQUEST']._serialized_end=433
+  _globals['_GETCOMMITDIFFREPLY']._serialized_start=435
+  _globals['_GETCOMMITDIFFREPLY']._serialized_end=508
+  _globals['_SEARCHSIMILARFAILURESREQUEST']._serialized_start=510
+  _globals['_SEARCHSIMILARFAILURESREQUEST']._serialized_end=588
+  _globals['_SIMILARFAILURE']._serialized_start=591
+  _globals['_SIMILARFAILURE']._serialized_end=738
+  _globals['_SEARCHSIMILARFAILURESREPLY']._serialized_start=740
+  _globals['_SEARCHSIMILARFAILURESREPLY']._serialized_end=819
+  _globals['_TOOLS']._serialized_start=822
+  _globals['_TOOLS']._serialized_end=1296
+# @@protoc_insertion_point(module_scope)
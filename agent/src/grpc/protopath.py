@@ -0,0 +1,7 @@
+"""生成的代码以 proto 目录为根互相导入（如 from analyzer.v1 import analyzer_pb2），导入生成代码前先导入本模块"""
+import os
+import sys
+
+PROTO_DIR = os.path.join(os.path.dirname(os.path.abspath(__file__)), "proto")
+if PROTO_DIR not in sys.path:
+    sys.path.insert(0, PROTO_DIR)
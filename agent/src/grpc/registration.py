@@ -0,0 +1,87 @@
+import logging
+import os
+import socket
+import threading
+
+import grpc
+import protopath  # noqa: F401
+from analyzer.v1 import registry_pb2 as pb2
+from analyzer.v1 import registry_pb2_grpc as pb2_grpc
+
+from version import version_metadata
+
+AGENT_VERSION = "0.1.0"
+ANALYSIS_TYPES = ["build_failure"]
+TOOLS = ["get_job", "list_recent_runs", "get_log_range", "get_commit_diff", "search_similar_failures"]
+
+logger = logging.getLogger(__name__)
+
+
+def agent_info(address):
+    """汇总本代理的模型、上下文上限和分析类型"""
+    from agent.llms.llms import llms, max_context_tokens
+
+    return pb2.AgentInfo(
+        id=os.getenv("AGENT_ID") or f"{socket.gethostname()}:{address.rsplit(':', 1)[-1]}",
+        address=address,
+        version=AGENT_VERSION,
+        models=[pb2.ModelInfo(name=name, max_context_tokens=max_context_tokens.get(name, 0)) for name in llms],
+        analysis_types=ANALYSIS_TYPES,
+        tools=TOOLS,
+    )
+
+
+def backend_metadata():
+    """调用后端时携带的 metadata：合约版本，以及 BACKEND_TOKEN 设置时的 authorization: Bearer <token>"""
+    token = os.getenv("BACKEND_TOKEN")
+    return version_metadata() + ([("authorization", f"Bearer {token}")] if token else [])
+
+
+def _channel(target):
+    """BACKEND_TLS_CA_FILE 设置后以 TLS 连接后端，再设置 BACKEND_TLS_CERT_FILE/KEY_FILE 时出示客户端证书"""
+    ca_file = os.getenv("BACKEND_TLS_CA_FILE")
+    if not ca_file:
+        return grpc.insecure_channel(target)
+
+    def read(path):
+        with open(path, "rb") as f:
+            return f.read()
+
+    cert_file, key_file = os.getenv("BACKEND_TLS_CERT_FILE"), os.getenv("BACKEND_TLS_KEY_FILE")
+    credentials = grpc.ssl_channel_credentials(
+        root_certificates=read(ca_file),
+        private_key=read(key_file) if key_file else None,
+        certificate_chain=read(cert_file) if cert_file else None,
+    )
+    return grpc.secure_channel(target, credentials)
+
+
+class Registration:
+    """定期调用后端 Registry.Register 上报能力，间隔由后端返回；stop 时注销"""
+
+    def __init__(self, target, address):
+        self._stub = pb2_grpc.RegistryStub(_channel(target))
+        self._info = agent_info(address)
+        self._metadata = backend_metadata()
+        self._stopped = threading.Event()
+        self._thread = threading.Thread(target=self._loop, daemon=True)
+
+    def start(self):
+        self._thread.start()
+
+    def _loop(self):
+        interval = 10
+        while not self._stopped.is_set():
+            try:
+                reply = self._stub.Register(pb2.RegisterRequest(agent=self._info), metadata=self._metadata, timeout=5)
+                interval = reply.heartbeat_interval_seconds or interval
+            except grpc.RpcError as e:
+                logger.warning("register with backend failed: %s", e)
+            self._stopped.wait(interval)
+
+    def stop(self):
+        self._stopped.set()
+        try:
+            self._stub.Deregister(pb2.DeregisterRequest(id=self._info.id), metadata=self._metadata, timeout=5)
+        except grpc.RpcError as e:
+            logger.warning("deregister from backend failed: %s", e)
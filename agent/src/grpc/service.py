@@ -1,6 +1,15 @@
+import hmac
+import os
+import socket
+
 import grpc
+from grpc_health.v1 import health, health_pb2, health_pb2_grpc
+import protopath  # noqa: F401
 import hello_pb2_grpc as pb2_grpc
 import hello_pb2 as pb2
+from analyzer.v1 import analyzer_pb2_grpc
+from analysis import Analyzer
+from version import APIVersionInterceptor
 from concurrent import futures
 from time import sleep
 
@@ -13,15 +22,87 @@ class Greeter(pb2_grpc.GreeterServicer):
         return pb2.HelloReply(message=message, age=age)
     
 
+class TokenInterceptor(grpc.ServerInterceptor):
+    """校验后端携带的 authorization: Bearer <token>，健康检查不需要 token"""
+
+    def __init__(self, token):
+        self._token = token
+
+        def deny(request, context):
+            context.abort(grpc.StatusCode.UNAUTHENTICATED, "invalid agent token")
+
+        self._deny = grpc.unary_unary_rpc_method_handler(deny)
+
+    def intercept_service(self, continuation, handler_call_details):
+        if handler_call_details.method.startswith("/grpc.health.v1.Health/"):
+            return continuation(handler_call_details)
+        for key, value in handler_call_details.invocation_metadata or ():
+            if key == "authorization" and hmac.compare_digest(value, f"Bearer {self._token}"):
+                return continuation(handler_call_details)
+        return self._deny
+
+
+def _read(path):
+    with open(path, "rb") as f:
+        return f.read()
+
+
+def server_credentials():
+    """AGENT_TLS_CERT_FILE/AGENT_TLS_KEY_FILE 启用 TLS，再设置 AGENT_TLS_CA_FILE 时要求客户端证书（mTLS）"""
+    cert_file = os.getenv("AGENT_TLS_CERT_FILE")
+    key_file = os.getenv("AGENT_TLS_KEY_FILE")
+    if not cert_file and not key_file:
+        return None
+    if not (cert_file and key_file):
+        raise ValueError("AGENT_TLS_CERT_FILE and AGENT_TLS_KEY_FILE must be set together")
+    ca_file = os.getenv("AGENT_TLS_CA_FILE")
+    return grpc.ssl_server_credentials(
+        [(_read(key_file), _read(cert_file))],
+        root_certificates=_read(ca_file) if ca_file else None,
+        require_client_auth=bool(ca_file),
+    )
+
+
 def serve():
+    interceptors = [APIVersionInterceptor()]
+    token = os.getenv("AGENT_TOKEN")
+    if token:
+        interceptors.append(TokenInterceptor(token))
     grpc_server = grpc.server(
-        futures.ThreadPoolExecutor(max_workers=10)
+        futures.ThreadPoolExecutor(max_workers=10),
+        interceptors=interceptors,
     )
     pb2_grpc.add_GreeterServicer_to_server(Greeter(), grpc_server)
-    grpc_server.add_insecure_port("0.0.0.0:50051")
-    print("gRPC server is running on port 50051...")
+    from agent.llms.llms import get_llm
+
+    analyzer_pb2_grpc.add_AnalyzerServicer_to_server(Analyzer(get_llm), grpc_server)
+
+    # 标准 gRPC 健康检查，供后端就绪探针使用
+    health_servicer = health.HealthServicer()
+    health_pb2_grpc.add_HealthServicer_to_server(health_servicer, grpc_server)
+    health_servicer.set("", health_pb2.HealthCheckResponse.SERVING)
+    credentials = server_credentials()
+    if credentials is None:
+        grpc_server.add_insecure_port("0.0.0.0:50051")
+    else:
+        grpc_server.add_secure_port("0.0.0.0:50051", credentials)
+    print(f"gRPC server is running on port 50051 (tls={credentials is not None})...")
     grpc_server.start()
-    grpc_server.wait_for_termination()
+
+    # 设置 BACKEND_REGISTRY_ADDRESS 后向后端注册，后端按模型把分析请求路由到本代理
+    registration = None
+    backend = os.getenv("BACKEND_REGISTRY_ADDRESS")
+    if backend:
+        from registration import Registration
+
+        advertise = os.getenv("AGENT_ADVERTISE_ADDRESS") or f"{socket.gethostname()}:50051"
+        registration = Registration(backend, advertise)
+        registration.start()
+    try:
+        grpc_server.wait_for_termination()
+    finally:
+        if registration is not None:
+            registration.stop()
 
 
 if __name__ == "__main__":
@@ -0,0 +1,36 @@
+import protopath  # noqa: F401
+from analyzer.v1 import tools_pb2 as pb2
+from analyzer.v1 import tools_pb2_grpc as pb2_grpc
+
+from registration import _channel, backend_metadata
+
+TOOL_TOKEN_KEY = "x-civ-tool-token"
+
+
+class BackendTools:
+    """分析过程中回调后端的只读工具，tool_token 取自 AnalyzeStart.tool_token，只在本次分析进行中有效；
+    与注册相同，后端的 grpc.token 通过 BACKEND_TOKEN 携带"""
+
+    def __init__(self, target, tool_token, timeout=10):
+        self._stub = pb2_grpc.ToolsStub(_channel(target))
+        self._metadata = backend_metadata() + [(TOOL_TOKEN_KEY, tool_token)]
+        self._timeout = timeout
+
+    def _call(self, method, request):
+        return method(request, metadata=self._metadata, timeout=self._timeout)
+
+    def get_job(self, job_id=0):
+        return self._call(self._stub.GetJob, pb2.GetJobRequest(job_id=job_id))
+
+    def list_recent_runs(self, name="", branch="", limit=10):
+        return self._call(self._stub.ListRecentRuns, pb2.ListRecentRunsRequest(name=name, branch=branch, limit=limit)).runs
+
+    def get_log_range(self, offset, length, job_id=0):
+        return self._call(self._stub.GetLogRange, pb2.GetLogRangeRequest(job_id=job_id, offset=offset, length=length))
+
+    def get_commit_diff(self, commit_sha="", max_bytes=0):
+        return self._call(self._stub.GetCommitDiff, pb2.GetCommitDiffRequest(commit_sha=commit_sha, max_bytes=max_bytes))
+
+    def search_similar_failures(self, query, category="", limit=5):
+        request = pb2.SearchSimilarFailuresRequest(query=query, category=category, limit=limit)
+        return self._call(self._stub.SearchSimilarFailures, request).failures
@@ -0,0 +1,75 @@
+import threading
+import time
+
+from langchain_core.callbacks import BaseCallbackHandler
+
+import protopath  # noqa: F401
+from analyzer.v1 import analyzer_pb2 as pb2
+
+
+class UsageTracker(BaseCallbackHandler):
+    """累计一次分析中所有模型调用的 token 数和耗时，作为 LangChain 回调传给每次调用：
+    llm.invoke(messages, config={"callbacks": [tracker]})，分析结束后把 tracker.to_proto() 填入 AnalyzeReply.usage。
+    on_update 在每次模型调用结束后以累计用量调用，流式分析中用它发送 AnalyzeStreamReply(usage=...)，
+    分析失败或被取消时后端按最后收到的用量记录"""
+
+    def __init__(self, model="", on_update=None):
+        self.model = model
+        self.on_update = on_update
+        self.prompt_tokens = 0
+        self.completion_tokens = 0
+        self.latency_ms = 0
+        self._started = {}
+        self._lock = threading.Lock()
+
+    def on_llm_start(self, serialized, prompts, *, run_id, **kwargs):
+        self._started[run_id] = time.monotonic()
+
+    def on_chat_model_start(self, serialized, messages, *, run_id, **kwargs):
+        self._started[run_id] = time.monotonic()
+
+    def on_llm_end(self, response, *, run_id, **kwargs):
+        started = self._started.pop(run_id, None)
+        prompt_tokens, completion_tokens, model = _token_usage(response)
+        with self._lock:
+            self.prompt_tokens += prompt_tokens
+            self.completion_tokens += completion_tokens
+            if model:
+                self.model = model
+            if started is not None:
+                self.latency_ms += int((time.monotonic() - started) * 1000)
+        if self.on_update is not None:
+            self.on_update(self.to_proto())
+
+    def on_llm_error(self, error, *, run_id, **kwargs):
+        self._started.pop(run_id, None)
+
+    def to_proto(self):
+        with self._lock:
+            return pb2.Usage(
+                model=self.model,
+                prompt_tokens=self.prompt_tokens,
+                completion_tokens=self.completion_tokens,
+                latency_ms=self.latency_ms,
+            )
+
+
+def _token_usage(response):
+    """优先读取消息上的 usage_metadata，没有时读取 llm_output 中供应商返回的 token_usage"""
+    llm_output = response.llm_output or {}
+    model = llm_output.get("model_name", "")
+    prompt_tokens = completion_tokens = 0
+    for generations in response.generations:
+        for generation in generations:
+            message = getattr(generation, "message", None)
+            usage = getattr(message, "usage_metadata", None)
+            if usage:
+                prompt_tokens += usage.get("input_tokens", 0)
+                completion_tokens += usage.get("output_tokens", 0)
+            if message is not None and not model:
+                model = message.response_metadata.get("model_name", "")
+    if not prompt_tokens and not completion_tokens:
+        token_usage = llm_output.get("token_usage") or {}
+        prompt_tokens = token_usage.get("prompt_tokens", 0)
+        completion_tokens = token_usage.get("completion_tokens", 0)
+    return prompt_tokens, completion_tokens, model
@@ -0,0 +1,53 @@
+import grpc
+
+# 与 backend/proto/analyzer/v1/version.go 保持一致
+API_VERSION = "v1"
+API_VERSION_KEY = "x-civ-api-version"
+VERSIONED_PREFIX = "/civ.analyzer.v1."
+
+
+def version_metadata():
+    return [(API_VERSION_KEY, API_VERSION)]
+
+
+def _with_version_header(handler):
+    """包装 handler，在响应 header 中返回本代理的版本，调用之后失败时后端也能确认双方版本一致"""
+
+    def wrap(behavior):
+        if behavior is None:
+            return None
+
+        def wrapped(request, context):
+            context.send_initial_metadata(version_metadata())
+            return behavior(request, context)
+
+        return wrapped
+
+    return handler._replace(
+        unary_unary=wrap(handler.unary_unary),
+        unary_stream=wrap(handler.unary_stream),
+        stream_unary=wrap(handler.stream_unary),
+        stream_stream=wrap(handler.stream_stream),
+    )
+
+
+class APIVersionInterceptor(grpc.ServerInterceptor):
+    """拒绝合约版本不兼容的调用，trailer 中返回本代理的版本，后端据此给出 AgentVersionMismatch；
+    接受的调用在 header 中返回版本"""
+
+    def __init__(self):
+        def deny(request, context):
+            context.set_trailing_metadata(version_metadata())
+            context.abort(grpc.StatusCode.FAILED_PRECONDITION, f"unsupported api version, agent speaks {API_VERSION}")
+
+        self._deny = grpc.unary_unary_rpc_method_handler(deny)
+
+    def intercept_service(self, continuation, handler_call_details):
+        if not handler_call_details.method.startswith(VERSIONED_PREFIX):
+            return continuation(handler_call_details)
+        for key, value in handler_call_details.invocation_metadata or ():
+            if key == API_VERSION_KEY and value == API_VERSION:
+                handler = continuation(handler_call_details)
+                # 未实现的方法 handler 为 None，由 gRPC 返回 UNIMPLEMENTED
+                return _with_version_header(handler) if handler is not None else None
+        return self._deny
@@ -0,0 +1,120 @@
+import os
+import queue
+import sys
+import unittest
+import uuid
+from concurrent import futures
+
+sys.path.insert(0, os.path.join(os.path.dirname(__file__), "..", "..", "src", "grpc"))
+
+import grpc  # noqa: E402
+from langchain_core.messages import AIMessage  # noqa: E402
+from langchain_core.outputs import ChatGeneration, LLMResult  # noqa: E402
+import protopath  # noqa: E402,F401
+from analyzer.v1 import analyzer_pb2 as pb2  # noqa: E402
+from analyzer.v1 import analyzer_pb2_grpc as pb2_grpc  # noqa: E402
+
+from analysis import Analyzer, parse_reply  # noqa: E402
+from version import API_VERSION, API_VERSION_KEY, APIVersionInterceptor, version_metadata  # noqa: E402
+
+LOG = b"go build ./...\nmain.go:3: undefined: foo\n"
+
+
+class FakeLLM:
+    """按 LangChain 的回调顺序报告一次调用的用量，之后返回 content 或抛出 error"""
+
+    def __init__(self, content="", error=None):
+        self.content = content
+        self.error = error
+        self.messages = None
+
+    def invoke(self, messages, config):
+        self.messages = messages
+        run_id = uuid.uuid4()
+        message = AIMessage(
+            content=self.content,
+            usage_metadata={"input_tokens": 120, "output_tokens": 30, "total_tokens": 150},
+            response_metadata={"model_name": "deepseek-chat"},
+        )
+        for callback in config["callbacks"]:
+            callback.on_chat_model_start({}, [messages], run_id=run_id)
+            callback.on_llm_end(LLMResult(generations=[[ChatGeneration(message=message)]]), run_id=run_id)
+        if self.error is not None:
+            raise self.error
+        return message
+
+
+class AnalyzerTest(unittest.TestCase):
+    def serve(self, llm):
+        server = grpc.server(futures.ThreadPoolExecutor(max_workers=2), interceptors=[APIVersionInterceptor()])
+        pb2_grpc.add_AnalyzerServicer_to_server(Analyzer(lambda model: llm if model == "deepseek" else None), server)
+        port = server.add_insecure_port("127.0.0.1:0")
+        server.start()
+        self.addCleanup(server.stop, None)
+        channel = grpc.insecure_channel(f"127.0.0.1:{port}")
+        self.addCleanup(channel.close)
+        return pb2_grpc.AnalyzerStub(channel)
+
+    def analyze_stream(self, stub, model="deepseek"):
+        """与后端相同：发送 AnalyzeStart，按代理的 LogRequest 发送日志分片。
+        返回收到的消息、调用对象和流结束时的错误（正常结束为 None）"""
+        requests = queue.Queue()
+        requests.put(pb2.AnalyzeStreamRequest(start=pb2.AnalyzeStart(
+            analysis_id=1,
+            job=pb2.Job(id=1, project="civ", name="build", status="failed"),
+            model=model,
+            log_size=len(LOG),
+        )))
+        call = stub.AnalyzeStream(iter(requests.get, None), metadata=version_metadata())
+        replies, error = [], None
+        try:
+            for reply in call:
+                replies.append(reply)
+                if reply.WhichOneof("payload") == "log_request":
+                    r = reply.log_request
+                    chunk = pb2.LogChunk(offset=r.offset, data=LOG[r.offset:r.offset + r.length], last=True)
+                    requests.put(pb2.AnalyzeStreamRequest(chunk=chunk))
+        except grpc.RpcError as e:
+            error = e
+        finally:
+            requests.put(None)
+        return replies, call, error
+
+    def test_stream_returns_result_with_usage(self):
+        llm = FakeLLM('```json\n{"summary": "foo is undefined", "root_cause": "missing import", '
+                      '"suggestion": "import foo", "category": "compile"}\n```')
+        replies, call, error = self.analyze_stream(self.serve(llm))
+
+        self.assertIsNone(error)
+        self.assertEqual(["log_request", "result"], [r.WhichOneof("payload") for r in replies])
+        self.assertIn("undefined: foo", llm.messages[-1].content, "the log tail is sent to the model")
+        result = replies[-1].result
+        self.assertEqual("foo is undefined", result.summary)
+        self.assertEqual("compile", result.category)
+        self.assertEqual("deepseek-chat", result.usage.model)
+        self.assertEqual(120, result.usage.prompt_tokens)
+        self.assertEqual(30, result.usage.completion_tokens)
+        self.assertIn((API_VERSION_KEY, API_VERSION), call.initial_metadata(), "accepted calls advertise the version")
+
+    def test_stream_failure_reports_usage(self):
+        replies, _, error = self.analyze_stream(self.serve(FakeLLM(error=RuntimeError("model returned garbage"))))
+
+        self.assertEqual(["log_request", "usage"], [r.WhichOneof("payload") for r in replies])
+        self.assertEqual(120, replies[-1].usage.prompt_tokens, "usage is reported before the stream fails")
+        self.assertEqual(grpc.StatusCode.INTERNAL, error.code())
+        self.assertNotIn("garbage", error.details(), "model errors are not sent to the backend")
+
+    def test_stream_rejects_unsupported_model(self):
+        replies, _, error = self.analyze_stream(self.serve(FakeLLM()), model="gpt")
+
+        self.assertEqual([], replies)
+        self.assertEqual(grpc.StatusCode.INVALID_ARGUMENT, error.code())
+
+
+class ParseReplyTest(unittest.TestCase):
+    def test_plain_text_becomes_summary(self):
+        self.assertEqual(pb2.AnalyzeReply(summary="the build ran out of disk"), parse_reply(" the build ran out of disk\n"))
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,67 @@
+import os
+import sys
+import unittest
+from concurrent import futures
+from unittest import mock
+
+sys.path.insert(0, os.path.join(os.path.dirname(__file__), "..", "..", "src", "grpc"))
+
+import grpc  # noqa: E402
+import protopath  # noqa: E402,F401
+from analyzer.v1 import analyzer_pb2  # noqa: E402
+from analyzer.v1 import tools_pb2_grpc  # noqa: E402
+
+from tools import TOOL_TOKEN_KEY, BackendTools  # noqa: E402
+
+BACKEND_TOKEN = "s3cret"
+
+
+class TokenInterceptor(grpc.ServerInterceptor):
+    """与后端 UnaryServerToken 一致：没有 authorization: Bearer <token> 的调用返回 UNAUTHENTICATED"""
+
+    def __init__(self, token):
+        def deny(request, context):
+            context.abort(grpc.StatusCode.UNAUTHENTICATED, "missing or invalid token")
+
+        self._token = token
+        self._deny = grpc.unary_unary_rpc_method_handler(deny)
+
+    def intercept_service(self, continuation, handler_call_details):
+        metadata = dict(handler_call_details.invocation_metadata or ())
+        if metadata.get("authorization") == f"Bearer {self._token}":
+            return continuation(handler_call_details)
+        return self._deny
+
+
+class FakeTools(tools_pb2_grpc.ToolsServicer):
+    def GetJob(self, request, context):
+        metadata = dict(context.invocation_metadata())
+        return analyzer_pb2.Job(id=1, name=metadata.get(TOOL_TOKEN_KEY, ""))
+
+
+class BackendToolsTest(unittest.TestCase):
+    def setUp(self):
+        self.server = grpc.server(futures.ThreadPoolExecutor(max_workers=2), interceptors=[TokenInterceptor(BACKEND_TOKEN)])
+        tools_pb2_grpc.add_ToolsServicer_to_server(FakeTools(), self.server)
+        port = self.server.add_insecure_port("127.0.0.1:0")
+        self.server.start()
+        self.addCleanup(self.server.stop, None)
+        self.target = f"127.0.0.1:{port}"
+
+    def test_sends_backend_token(self):
+        with mock.patch.dict(os.environ, {"BACKEND_TOKEN": BACKEND_TOKEN}):
+            tools = BackendTools(self.target, "tool-token")
+        job = tools.get_job()
+        self.assertEqual(1, job.id)
+        self.assertEqual("tool-token", job.name, "tool token is sent alongside the bearer token")
+
+    def test_rejected_without_backend_token(self):
+        with mock.patch.dict(os.environ, {"BACKEND_TOKEN": ""}):
+            tools = BackendTools(self.target, "tool-token")
+        with self.assertRaises(grpc.RpcError) as ctx:
+            tools.get_job()
+        self.assertEqual(grpc.StatusCode.UNAUTHENTICATED, ctx.exception.code())
+
+
+if __name__ == "__main__":
+    unittest.main()
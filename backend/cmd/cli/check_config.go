@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"civ/config"
+	"fmt"
+)
+
+// runCheckConfig loads and validates the configuration selected by the flags
+// and the CIV_ environment without starting the server.
+func runCheckConfig(args []string) error {
+	var opts config.Options
+	if err := newFlagSet("check-config", &opts).Parse(args); err != nil {
+		return err
+	}
+	if _, err := config.LoadConfig(opts); err != nil {
+		return err
+	}
+	fmt.Println("config OK")
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package cli implements the subcommands of the backend binary. Every command
+// accepts -config and -profile and shares config loading and database setup.
+package cli
+
+import (
+	"civ/config"
+	"civ/data"
+	apperrors "civ/internal/pkg/errors"
+	"civ/internal/pkg/logger"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"serve", "start the HTTP server (default)", runServe},
+		{"migrate", "apply or roll back schema migrations: migrate up|down|status", runMigrate},
+		{"check-config", "validate the configuration without starting the server", runCheckConfig},
+		{"user", "manage users: user create", runUser},
+		{"token", "manage API tokens: token issue", runToken},
+		{"reanalyze", "analyze a job again: reanalyze -job ID", runReanalyze},
+		{"import", "import CI job runs from a JSON Lines file", runImport},
+		{"version", "print version information", runVersion},
+		{"help", "show this help", runHelp},
+	}
+}
+
+// Run dispatches args (without the program name) to a subcommand and returns the
+// process exit code. Without a subcommand the server is started, so existing
+// deployments that run the bare binary keep working.
+func Run(args []string) int {
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		if err := cmd.run(args); err != nil {
+			if err != flag.ErrHelp {
+				fmt.Fprintln(os.Stderr, "error:", err)
+			}
+			return 1
+		}
+		return 0
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage(os.Stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: civ <command> [flags]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-13s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(w, "\nRun 'civ <command> -h' for the flags of a command.")
+}
+
+func runHelp(args []string) error {
+	usage(os.Stdout)
+	return nil
+}
+
+// newFlagSet creates the flag set of a subcommand with the shared -config and -profile flags.
+func newFlagSet(name string, opts *config.Options) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	opts.RegisterFlags(flags)
+	return flags
+}
+
+// setup loads the configuration selected by opts, configures logging, loads the
+// error message catalog and connects to MySQL.
+func setup(opts config.Options) (*config.Config, error) {
+	cfg, err := config.Init(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := logger.Init(cfg.System.Log); err != nil {
+		return nil, err
+	}
+	if err := apperrors.Init(cfg.System.MessagesDir); err != nil {
+		return nil, err
+	}
+	if _, err := data.IniDB(); err != nil {
+		return nil, fmt.Errorf("connect database: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bufio"
+	"civ/config"
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/service"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// importRecord 导入文件中每行一个 JSON 对象
+type importRecord struct {
+	Project    string     `json:"project"`
+	ExternalID string     `json:"external_id"`
+	Name       string     `json:"name"`
+	Branch     string     `json:"branch"`
+	CommitSHA  string     `json:"commit_sha"`
+	Status     string     `json:"status"`
+	LogPath    string     `json:"log_path"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// runImport upserts CI job runs from a JSON Lines file, one job per line, keyed
+// by (project, external_id) so the same export can be imported repeatedly.
+func runImport(args []string) error {
+	var opts config.Options
+	flags := newFlagSet("import", &opts)
+	file := flags.String("file", "", "JSON Lines file to import, - for stdin (required)")
+	project := flags.String("project", "", "project for records that do not set one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if _, err := setup(opts); err != nil {
+		return err
+	}
+	defer data.CloseDB()
+
+	jobs := service.NewJobService()
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	var imported, failed int
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec importRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", line, err)
+			failed++
+			continue
+		}
+		if rec.Project == "" {
+			rec.Project = *project
+		}
+		job := &model.Job{
+			Project:    rec.Project,
+			ExternalID: rec.ExternalID,
+			Name:       rec.Name,
+			Branch:     rec.Branch,
+			CommitSHA:  rec.CommitSHA,
+			Status:     rec.Status,
+			LogPath:    rec.LogPath,
+			StartedAt:  rec.StartedAt,
+			FinishedAt: rec.FinishedAt,
+		}
+		if err := jobs.Upsert(context.Background(), job); err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", line, err)
+			failed++
+			continue
+		}
+		imported++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	fmt.Printf("imported %d jobs, %d failed\n", imported, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d records failed to import", failed)
+	}
+	return nil
+}
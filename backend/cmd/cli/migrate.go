@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"civ/config"
+	"civ/data"
+	"civ/data/migrate"
+	"errors"
+	"fmt"
+)
+
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down|status [flags]")
+	}
+	action, args := args[0], args[1:]
+
+	var opts config.Options
+	flags := newFlagSet("migrate "+action, &opts)
+	steps := flags.Int("steps", 1, "number of migrations to roll back (down only)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if _, err := setup(opts); err != nil {
+		return err
+	}
+	defer data.CloseDB()
+
+	switch action {
+	case "up":
+		done, err := migrate.Up(data.DB)
+		for _, id := range done {
+			fmt.Println("applied", id)
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Println("database is up to date")
+		}
+		return err
+	case "down":
+		done, err := migrate.Down(data.DB, *steps)
+		for _, id := range done {
+			fmt.Println("rolled back", id)
+		}
+		return err
+	case "status":
+		statuses, err := migrate.List(data.DB)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-45s %s\n", s.ID, state)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown migrate action %q, want up, down or status", action)
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"civ/config"
+	"civ/internal/model"
+	apperrors "civ/internal/pkg/errors"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reanalyzePollInterval 等待分析完成时查询分析记录的间隔
+const reanalyzePollInterval = time.Second
+
+// runReanalyze 通过服务端的 POST /api/jobs/:id/analyses 发起分析并等待结果。分析在服务端进行，
+// 按服务端已注册的代理路由，也可以在服务端查询进度和取消
+func runReanalyze(args []string) error {
+	var opts config.Options
+	flags := newFlagSet("reanalyze", &opts)
+	jobID := flags.Uint("job", 0, "ID of the job to analyze again (required)")
+	modelName := flags.String("model", "", "model to analyze with, defaults to agent.model")
+	server := flags.String("server", "", "base URL of the server, defaults to the configured system.host and system.port")
+	token := flags.String("token", os.Getenv("CIV_API_TOKEN"), "API token the analysis and its usage are attributed to, defaults to $CIV_API_TOKEN")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *jobID == 0 {
+		return errors.New("-job is required")
+	}
+	if *server == "" {
+		cfg, err := config.LoadConfig(opts)
+		if err != nil {
+			return err
+		}
+		*server = serverURL(cfg.System.Host, cfg.System.Port)
+	}
+
+	client := &apiClient{base: strings.TrimRight(*server, "/"), token: *token}
+	ctx := context.Background()
+	var analysis model.Analysis
+	body := map[string]string{"model": *modelName}
+	if err := client.do(ctx, http.MethodPost, fmt.Sprintf("/api/jobs/%d/analyses", *jobID), body, &analysis); err != nil {
+		return err
+	}
+	fmt.Printf("analysis %d for job %d: %s\n", analysis.ID, *jobID, analysis.Status)
+	for analysis.Status == model.AnalysisPending || analysis.Status == model.AnalysisRunning {
+		time.Sleep(reanalyzePollInterval)
+		if err := client.do(ctx, http.MethodGet, fmt.Sprintf("/api/analyses/%d", analysis.ID), nil, &analysis); err != nil {
+			return err
+		}
+	}
+	if analysis.Status != model.AnalysisSucceeded {
+		return fmt.Errorf("analysis %d %s: %s", analysis.ID, analysis.Status, analysis.Error)
+	}
+	fmt.Printf("category:   %s\nsummary:    %s\nroot cause: %s\nsuggestion: %s\n",
+		analysis.Category, analysis.Summary, analysis.RootCause, analysis.Suggestion)
+	return nil
+}
+
+// serverURL 返回本机访问服务端的地址，监听所有地址时使用回环地址
+func serverURL(host string, port int) string {
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// apiClient 调用服务端 HTTP 接口，token 非空时以 Authorization: Bearer 发送
+type apiClient struct {
+	base  string
+	token string
+}
+
+// do 发送请求并把响应 data 解码到 out，业务码非 0 时以响应的 msg 返回错误
+func (c *apiClient) do(ctx context.Context, method, path string, body, out any) error {
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, payload)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Code int             `json:"code"`
+		Msg  string          `json:"msg"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("%s %s: unexpected response (HTTP %d): %w", method, path, resp.StatusCode, err)
+	}
+	if result.Code != apperrors.SUCCESS {
+		return fmt.Errorf("%s %s: %s (code %d)", method, path, result.Msg, result.Code)
+	}
+	return json.Unmarshal(result.Data, out)
+}
@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"civ/cmd/server"
+	"civ/config"
+	"flag"
+)
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	server.RunServer()
+	return nil
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"civ/config"
+	"civ/data"
+	"civ/internal/service"
+	"context"
+	"errors"
+	"fmt"
+)
+
+func runToken(args []string) error {
+	if len(args) == 0 || args[0] != "issue" {
+		return errors.New("usage: token issue -user NAME [-name LABEL] [-ttl DURATION]")
+	}
+
+	var opts config.Options
+	flags := newFlagSet("token issue", &opts)
+	username := flags.String("user", "", "owner of the token (required)")
+	name := flags.String("name", "cli", "label shown when listing tokens")
+	ttl := flags.Duration("ttl", 0, "lifetime such as 720h; 0 never expires")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("-user is required")
+	}
+
+	if _, err := setup(opts); err != nil {
+		return err
+	}
+	defer data.CloseDB()
+
+	plain, token, err := service.NewTokenService().Issue(context.Background(), *username, *name, *ttl)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("issued token %d for %s", token.ID, *username)
+	if token.ExpiresAt != nil {
+		fmt.Printf(", expires %s", token.ExpiresAt.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Println()
+	fmt.Println("store it now, it will not be shown again:")
+	fmt.Println(plain)
+	return nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bufio"
+	"civ/config"
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/service"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func runUser(args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errors.New("usage: user create -username NAME [-email EMAIL] [-role admin|member] (-password PASS | -password-stdin)")
+	}
+
+	var opts config.Options
+	flags := newFlagSet("user create", &opts)
+	username := flags.String("username", "", "login name (required)")
+	email := flags.String("email", "", "email address")
+	role := flags.String("role", model.RoleMember, "role: admin or member")
+	password := flags.String("password", "", "password; prefer -password-stdin to keep it out of shell history")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from the first line of stdin")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *passwordStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("read password: %w", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+	if *username == "" || *password == "" {
+		return errors.New("-username and a password are required")
+	}
+
+	if _, err := setup(opts); err != nil {
+		return err
+	}
+	defer data.CloseDB()
+
+	user, err := service.NewUserService().Create(context.Background(), *username, *email, *password, *role)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created user %s (id %d, role %s)\n", user.Username, user.ID, user.Role)
+	return nil
+}
@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"civ/internal/pkg/version"
+	"fmt"
+)
+
+func runVersion(args []string) error {
+	fmt.Println(version.String())
+	return nil
+}
@@ -2,21 +2,171 @@ package server
 
 import (
 	"civ/config"
+	"civ/data"
+	"civ/internal/agent"
+	"civ/internal/middleware"
+	apperrors "civ/internal/pkg/errors"
+	"civ/internal/pkg/health"
+	"civ/internal/pkg/logger"
+	"civ/internal/pkg/tracing"
+	"civ/internal/pkg/validation"
 	"civ/internal/routers"
-	"log"
+	"civ/internal/rpc"
+	"civ/internal/service"
+	pb "civ/proto/analyzer/v1"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 )
 
-// the process is terminated via log.Fatal.
+const defaultShutdownTimeout = 30 * time.Second
+
+// RunServer configures logging and tracing, connects to MySQL and the agent,
+// starts the HTTP server (and the gRPC server agents register with when
+// grpc.enabled is set) and blocks until SIGINT or SIGTERM is received.
+// Config file changes are hot-reloaded; the log level, agent timeout, agent
+// token and circuit breaker settings are applied live.
+//
+// On shutdown the readiness probe is flipped to not-ready first, then after
+// System.ShutdownDelay the server stops accepting connections and waits up to
+// System.ShutdownTimeout for in-flight requests. Startup failures terminate
+// the process via logger.Fatal.
 func RunServer() {
-	r := gin.Default()
+	cfg := config.GetConfig()
+	if err := logger.Init(cfg.System.Log); err != nil {
+		logger.Fatal("Logger Init Failed", "error", err)
+	}
+
+	if err := apperrors.Init(cfg.System.MessagesDir); err != nil {
+		logger.Fatal("Error Catalog Init Failed", "error", err)
+	}
+	if err := validation.Init(); err != nil {
+		logger.Fatal("Validator Init Failed", "error", err)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Trace)
+	if err != nil {
+		logger.Fatal("Tracing Init Failed", "error", err)
+	}
+
+	db, err := data.IniDB()
+	if err != nil {
+		logger.Fatal("Database Init Failed", "error", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Fatal("Database Init Failed", "error", err)
+	}
+	health.Default.Register("mysql", health.DBCheck(sqlDB))
 
-	config := config.GetConfig()
+	agentClient, err := agent.InitClient(cfg.Agent)
+	if err != nil {
+		logger.Fatal("Agent Client Init Failed", "error", err)
+	}
+	health.Default.Register("agent", health.GRPCCheck(agentClient.Conn(), ""))
+	if cfg.Agent.MaxRunning > 0 {
+		health.Default.Register("analysis_queue", health.QueueCheck(service.RunningAnalyses, cfg.Agent.MaxRunning))
+	}
+	registry := agent.InitRegistry(cfg.Agent)
+
+	config.Subscribe(func(_, next *config.Config) {
+		if err := logger.SetLevel(next.System.Log.Level); err != nil {
+			slog.Error("apply log level failed", "error", err)
+		}
+		agentClient.SetTimeout(next.Agent.Timeout)
+		agentClient.SetToken(next.Agent.Token)
+		agentClient.SetCircuitBreaker(next.Agent.CircuitBreaker)
+		registry.Reconfigure(next.Agent)
+	})
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if err := config.Watch(watchCtx); err != nil {
+		slog.Warn("config hot reload disabled", "error", err)
+	}
+	go registry.Run(watchCtx)
+
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Enabled {
+		if grpcServer, err = rpc.NewServer(cfg.GRPC); err != nil {
+			logger.Fatal("gRPC Server Init Failed", "error", err)
+		}
+		pb.RegisterRegistryServer(grpcServer, rpc.NewRegistryServer(registry))
+		pb.RegisterToolsServer(grpcServer, rpc.NewToolsServer(service.NewToolService()))
+		lis, err := net.Listen("tcp", cfg.GRPC.Address)
+		if err != nil {
+			logger.Fatal("gRPC Server Listen Failed", "error", err)
+		}
+		go func() {
+			slog.Info("grpc server listening", "addr", cfg.GRPC.Address)
+			if err := grpcServer.Serve(lis); err != nil {
+				logger.Fatal("gRPC Server Run Failed", "error", err)
+			}
+		}()
+	}
+
+	r := gin.New()
+	if cfg.Trace.Enabled {
+		r.Use(otelgin.Middleware(cfg.Trace.ServiceName))
+	}
+	r.Use(middleware.RequestLogger(), middleware.Recovery(), middleware.Language())
+	if cfg.Metrics.Enabled {
+		r.Use(middleware.Metrics())
+	}
 	routers.SetupRouter(r)
+
+	srv := &http.Server{
+		Addr:    net.JoinHostPort(cfg.System.Host, strconv.Itoa(cfg.System.Port)),
+		Handler: r,
+	}
 	go func() {
-		if err := r.Run(config.System.Host + ":" + string(rune(config.System.Port))); err != nil {
-			log.Fatal("Server Run Failed:", err)
+		slog.Info("server listening", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Server Run Failed", "error", err)
 		}
 	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+	slog.Info("shutting down", "signal", sig.String())
+
+	health.Default.SetShuttingDown()
+	system := config.GetConfig().System
+	time.Sleep(system.ShutdownDelay)
+
+	timeout := system.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("server shutdown failed", "error", err)
+	}
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	stopWatch()
+	if err := agentClient.Close(); err != nil {
+		slog.Error("agent client close failed", "error", err)
+	}
+	if err := data.CloseDB(); err != nil {
+		slog.Error("database close failed", "error", err)
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("tracing shutdown failed", "error", err)
+	}
+	slog.Info("server stopped")
 }
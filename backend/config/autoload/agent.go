@@ -0,0 +1,34 @@
+package autoload
+
+import "time"
+
+type AgentConfig struct {
+	// Address 单个代理地址 host:port，或 dns:///host:port 按 DNS 解析出的全部地址
+	Address string `mapstructure:"address" validate:"required_without=Endpoints,grpc_target" reload:"restart"`
+	// Endpoints 多个静态代理地址，设置后忽略 Address
+	Endpoints []string `mapstructure:"endpoints" validate:"dive,hostname_port" reload:"restart"`
+	// Balancer 多个代理之间的负载均衡策略
+	Balancer string `mapstructure:"balancer" validate:"oneof=round_robin least_loaded" reload:"restart"`
+	// HealthCheck 通过 gRPC 健康检查剔除不健康的代理
+	HealthCheck    bool                 `mapstructure:"health_check" reload:"restart"`
+	CircuitBreaker CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// HeartbeatInterval 通过 Register 注册的代理重复上报的间隔，超过三个间隔未上报视为下线
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval" validate:"min=0s" reload:"restart"`
+	// MaxRunning 本实例进行中的分析超过该数量时就绪检查失败，0 表示不检查
+	MaxRunning int           `mapstructure:"max_running" validate:"min=0" reload:"restart"`
+	Timeout    time.Duration `mapstructure:"timeout" validate:"min=0s"`
+	Model      string        `mapstructure:"model"`
+	// Prompt 分析使用的提示词模板名，见 /api/prompts；模板不存在时代理使用内置提示词
+	Prompt string `mapstructure:"prompt"`
+	// Token 每次调用以 authorization: Bearer <token> 发送，可热加载
+	Token string    `mapstructure:"token"`
+	TLS   TLSConfig `mapstructure:"tls" reload:"restart"`
+}
+
+// CircuitBreakerConfig 连续 FailureThreshold 次调用因代理不可用失败后熔断，
+// OpenTimeout 内直接失败，之后放行一次探测调用，成功则恢复
+type CircuitBreakerConfig struct {
+	// FailureThreshold 为 0 时不熔断
+	FailureThreshold int           `mapstructure:"failure_threshold" validate:"min=0"`
+	OpenTimeout      time.Duration `mapstructure:"open_timeout" validate:"min=0s"`
+}
@@ -0,0 +1,13 @@
+package autoload
+
+// GRPCConfig 后端自身的 gRPC 服务，供代理注册等调用
+type GRPCConfig struct {
+	Enabled bool   `mapstructure:"enabled" reload:"restart"`
+	Address string `mapstructure:"address" validate:"hostname_port" reload:"restart"`
+	// Token 非空时要求调用方携带 authorization: Bearer <token>，可热加载；
+	// 开启服务时必须设置 Token 或配置 mTLS（TLS.Enabled 且设置 TLS.CAFile）
+	Token string    `mapstructure:"token"`
+	TLS   TLSConfig `mapstructure:"tls" reload:"restart"`
+	// ToolSecret 签发分析工具令牌的密钥，开启服务时必须设置，多个后端实例需配置相同的值
+	ToolSecret string `mapstructure:"tool_secret" validate:"required_if=Enabled true" reload:"restart"`
+}
@@ -0,0 +1,11 @@
+package autoload
+
+type LogConfig struct {
+	Level      string `mapstructure:"level" validate:"omitempty,oneof=debug info warn warning error"`
+	Format     string `mapstructure:"format" validate:"omitempty,oneof=text json" reload:"restart"`
+	Output     string `mapstructure:"output" reload:"restart"`
+	MaxSize    int    `mapstructure:"max_size" validate:"min=0" reload:"restart"`
+	MaxBackups int    `mapstructure:"max_backups" validate:"min=0" reload:"restart"`
+	MaxAge     int    `mapstructure:"max_age" validate:"min=0" reload:"restart"`
+	Compress   bool   `mapstructure:"compress" reload:"restart"`
+}
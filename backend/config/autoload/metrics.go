@@ -0,0 +1,6 @@
+package autoload
+
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path" validate:"omitempty,startswith=/"`
+}
@@ -1,9 +1,9 @@
 package autoload
 
 type MySQLConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Username string `mapstructure:"username"`
+	Host     string `mapstructure:"host" validate:"required,hostname|ip"`
+	Port     int    `mapstructure:"port" validate:"min=1,max=65535"`
+	Username string `mapstructure:"username" validate:"required"`
 	Password string `mapstructure:"password"`
-	Database string `mapstructure:"database"`
+	Database string `mapstructure:"database" validate:"required"`
 }
@@ -0,0 +1,14 @@
+package autoload
+
+import "time"
+
+type SecretsConfig struct {
+	Vault VaultConfig `mapstructure:"vault"`
+}
+
+type VaultConfig struct {
+	Address   string        `mapstructure:"address" validate:"omitempty,url"`
+	Token     string        `mapstructure:"token"`
+	Namespace string        `mapstructure:"namespace"`
+	Timeout   time.Duration `mapstructure:"timeout" validate:"min=0s"`
+}
@@ -0,0 +1,7 @@
+package autoload
+
+type StorageConfig struct {
+	LogDir string `mapstructure:"log_dir"`
+	// RepoDir 代码仓库镜像目录，<RepoDir>/<project> 为项目的 git 仓库，供分析工具读取提交改动
+	RepoDir string `mapstructure:"repo_dir"`
+}
@@ -1,7 +1,14 @@
 package autoload
 
+import "time"
+
 type SystemConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Language string `mapstructure:"language"`
+	Host string `mapstructure:"host" validate:"omitempty,hostname|ip" reload:"restart"`
+	Port int    `mapstructure:"port" validate:"min=1,max=65535" reload:"restart"`
+	// Language 默认语言，可以是内置语言或 MessagesDir 中文案文件对应的语言
+	Language        string        `mapstructure:"language"`
+	MessagesDir     string        `mapstructure:"messages_dir" validate:"omitempty,dir" reload:"restart"`
+	ShutdownDelay   time.Duration `mapstructure:"shutdown_delay" validate:"min=0s"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"min=0s"`
+	Log             LogConfig     `mapstructure:"log"`
 }
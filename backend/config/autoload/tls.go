@@ -0,0 +1,13 @@
+package autoload
+
+// TLSConfig gRPC 连接的 TLS 配置。证书和 CA 文件被替换后，下次握手时自动加载新内容
+type TLSConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CAFile 校验对端证书的 CA，客户端为空时使用系统根证书；服务端设置后要求客户端证书（mTLS）
+	CAFile string `mapstructure:"ca_file"`
+	// CertFile/KeyFile 本端证书，客户端设置后启用 mTLS
+	CertFile string `mapstructure:"cert_file" validate:"required_with=KeyFile"`
+	KeyFile  string `mapstructure:"key_file" validate:"required_with=CertFile"`
+	// ServerName 客户端校验服务端证书时使用的名称，默认取连接地址中的主机名
+	ServerName string `mapstructure:"server_name"`
+}
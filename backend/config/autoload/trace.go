@@ -0,0 +1,10 @@
+package autoload
+
+type TraceConfig struct {
+	Enabled     bool              `mapstructure:"enabled"`
+	ServiceName string            `mapstructure:"service_name"`
+	Endpoint    string            `mapstructure:"endpoint" validate:"required_if=Enabled true,omitempty,hostname_port"`
+	Insecure    bool              `mapstructure:"insecure"`
+	Headers     map[string]string `mapstructure:"headers"`
+	SampleRatio float64           `mapstructure:"sample_ratio" validate:"min=0,max=1"`
+}
@@ -0,0 +1,21 @@
+package autoload
+
+// ModelPrice 模型单价，单位为 currency 每百万 token
+type ModelPrice struct {
+	Prompt     float64 `mapstructure:"prompt" validate:"gte=0"`
+	Completion float64 `mapstructure:"completion" validate:"gte=0"`
+}
+
+// BudgetConfig 项目的费用上限，0 表示不限制。Project 为空的条目作用于没有单独配置的项目
+type BudgetConfig struct {
+	Project string  `mapstructure:"project"`
+	Daily   float64 `mapstructure:"daily" validate:"gte=0"`
+	Monthly float64 `mapstructure:"monthly" validate:"gte=0"`
+}
+
+type UsageConfig struct {
+	Currency string `mapstructure:"currency"`
+	// Prices 按代理返回的模型名查找，代理未返回时使用分析请求的模型名；未配置价格的模型费用记为 0
+	Prices  map[string]ModelPrice `mapstructure:"prices" validate:"dive"`
+	Budgets []BudgetConfig        `mapstructure:"budgets" validate:"dive"`
+}
@@ -2,52 +2,274 @@ package config
 
 import (
 	"civ/config/autoload"
-	"log"
+	"civ/internal/pkg/logger"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	// EnvPrefix 环境变量前缀，如 CIV_MYSQL_PASSWORD 覆盖 mysql.password
+	EnvPrefix = "CIV"
+	// EnvConfigFile 指定配置文件路径的环境变量
+	EnvConfigFile = EnvPrefix + "_CONFIG"
+	// EnvProfile 指定环境名的环境变量，用于加载 config.<profile>.yaml
+	EnvProfile = EnvPrefix + "_PROFILE"
+)
+
 var (
-	appConfig *Config
+	appConfig atomic.Pointer[Config]
+	loadErr   error
 	once      sync.Once
+
+	defaultOptions Options
+	// watchFiles 为首次加载时使用的配置文件（含 profile 覆盖文件），供 Watch 监听
+	watchFiles []string
+	// loadedOptions 为首次加载时使用的选项，重新加载时沿用
+	loadedOptions Options
 )
 
+// Config 为应用配置。带有 reload:"restart" 标签的字段只在启动时生效，
+// 热加载时其变更会被记录为待重启项而不会应用。
 type Config struct {
-	MySQL  autoload.MySQLConfig  `mapstructure:"mysql"`
-	System autoload.SystemConfig `mapstructure:"system"`
+	MySQL    autoload.MySQLConfig   `mapstructure:"mysql" reload:"restart"`
+	System   autoload.SystemConfig  `mapstructure:"system"`
+	Agent    autoload.AgentConfig   `mapstructure:"agent"`
+	GRPC     autoload.GRPCConfig    `mapstructure:"grpc"`
+	Metrics  autoload.MetricsConfig `mapstructure:"metrics" reload:"restart"`
+	Trace    autoload.TraceConfig   `mapstructure:"trace" reload:"restart"`
+	Storage  autoload.StorageConfig `mapstructure:"storage"`
+	Usage    autoload.UsageConfig   `mapstructure:"usage"`
+	Secrets  autoload.SecretsConfig `mapstructure:"secrets"`
+	Features map[string]bool        `mapstructure:"features"`
+}
+
+// FeatureEnabled reports whether the named feature flag is switched on.
+func (c *Config) FeatureEnabled(name string) bool {
+	return c.Features[name]
+}
+
+// Options controls where LoadConfig looks for configuration.
+type Options struct {
+	// File is the base config file. When empty, CIV_CONFIG is used, then
+	// <workDir>/config/config.yaml if it exists.
+	File string
+	// Profile selects the overlay config.<profile>.yaml next to the base file.
+	// When empty, CIV_PROFILE is used.
+	Profile string
+}
+
+// RegisterFlags binds the -config and -profile flags to the options used by GetConfig.
+// It must be called before the flag set is parsed.
+func RegisterFlags(flags *flag.FlagSet) {
+	defaultOptions.RegisterFlags(flags)
+}
+
+// RegisterFlags binds the -config and -profile flags to o.
+func (o *Options) RegisterFlags(flags *flag.FlagSet) {
+	flags.StringVar(&o.File, "config", "", "path to config file (env "+EnvConfigFile+")")
+	flags.StringVar(&o.Profile, "profile", "", "config profile overlay, e.g. dev or prod (env "+EnvProfile+")")
+}
+
+// LoadConfig loads application configuration in layers and returns a populated Config.
+//
+// Layers are applied from lowest to highest precedence: built-in defaults, the base
+// YAML file, the profile overlay (config.<profile>.yaml in the same directory), and
+// CIV_-prefixed environment variables bound to every field (e.g. CIV_SYSTEM_LOG_LEVEL).
+// A missing default file is not an error, so defaults and environment variables alone
+// are enough to run; a file requested explicitly via Options or CIV_CONFIG must exist.
+// Secret references such as "file:///run/secrets/db" or "env:DB_PASSWORD" are then
+// resolved, and the result is checked with Validate before it is returned.
+func LoadConfig(opts Options) (*Config, error) {
+	config, _, err := load(opts)
+	return config, err
 }
 
-// LoadConfig loads application configuration from a file and returns a populated Config.
-// 
-// LoadConfig looks for a YAML file named `config.yaml` inside a `config` subdirectory of
-// the current working directory (i.e. `<workDir>/config/config.yaml`). It uses Viper to
-// read and unmarshal the file into a Config value and returns a pointer to that value.
-// If reading or unmarshalling the configuration fails, the function logs a fatal error and
-// terminates the program.
-func LoadConfig() *Config {
-	workDir, _ := os.Getwd()
-	configFile := filepath.Join(workDir, "config", "config.yaml")
-	log.Println("Loading config from ", configFile)
+// load implements LoadConfig and also returns the base and overlay file paths so
+// that Watch can observe them.
+func load(opts Options) (*Config, []string, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	file, explicit := opts.File, opts.File != ""
+	if file == "" {
+		file, explicit = os.Getenv(EnvConfigFile), os.Getenv(EnvConfigFile) != ""
+	}
+	if file == "" {
+		workDir, err := os.Getwd()
+		if err != nil {
+			return nil, nil, err
+		}
+		file = filepath.Join(workDir, "config", "config.yaml")
+	}
+
+	if err := readFile(v, file, false); err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			slog.Info("config file not found, using defaults and environment", "file", file)
+		} else {
+			return nil, nil, err
+		}
+	}
+
+	files := []string{file}
+	profile := opts.Profile
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile != "" {
+		overlay := profileFile(file, profile)
+		files = append(files, overlay)
+		if err := readFile(v, overlay, true); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, nil, err
+			}
+			slog.Info("config profile overlay not found", "profile", profile, "file", overlay)
+		}
+	}
 
-	viper.SetConfigFile(configFile)
-	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("Error reading config file, %s", err)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		if err := v.BindEnv(key); err != nil {
+			return nil, nil, err
+		}
 	}
-	log.Println("Using config file:", viper.AllSettings())
+	slog.Debug("using config", "settings", logger.Redact(v.AllSettings()))
 
 	var config Config
-	if err := viper.Unmarshal(&config); err != nil {
-		log.Fatalf("Error unmarshalling config, %s", err)
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal config: %w", err)
 	}
-	return &config
+	if err := resolveSecrets(&config); err != nil {
+		return nil, nil, fmt.Errorf("resolve secrets: %w", err)
+	}
+	if err := Validate(&config); err != nil {
+		return nil, nil, err
+	}
+	return &config, files, nil
 }
 
-func GetConfig() *Config {
+// Init loads the configuration once with the given options and caches it for GetConfig.
+// If the configuration has already been loaded, the cached value is returned.
+func Init(opts Options) (*Config, error) {
 	once.Do(func() {
-		appConfig = LoadConfig()
+		var config *Config
+		config, watchFiles, loadErr = load(opts)
+		if loadErr == nil {
+			loadedOptions = opts
+			appConfig.Store(config)
+		}
 	})
-	return appConfig
+	return appConfig.Load(), loadErr
+}
+
+// GetConfig returns the current configuration snapshot, loading it with the options
+// bound by RegisterFlags on first use. The snapshot is replaced as a whole on hot
+// reload, so callers should not keep it across requests. Since callers cannot handle a failure here, a load
+// error terminates the process.
+func GetConfig() *Config {
+	config, err := Init(defaultOptions)
+	if err != nil {
+		logger.Fatal("error loading config", "error", err)
+	}
+	return config
+}
+
+func readFile(v *viper.Viper, file string, merge bool) error {
+	if _, err := os.Stat(file); err != nil {
+		return err
+	}
+	slog.Info("loading config", "file", file)
+	v.SetConfigFile(file)
+	if merge {
+		if err := v.MergeInConfig(); err != nil {
+			return fmt.Errorf("merge config %s: %w", file, err)
+		}
+		return nil
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("read config %s: %w", file, err)
+	}
+	return nil
+}
+
+// profileFile returns the overlay path for profile, e.g. config/config.yaml -> config/config.dev.yaml.
+func profileFile(base, profile string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + profile + ext
+}
+
+// configKeys returns the dotted viper keys of every leaf field in t, following mapstructure tags.
+func configKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := tagName(field)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		switch {
+		case field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}):
+			keys = append(keys, configKeys(field.Type, key+".")...)
+		case field.Type.Kind() == reflect.Map:
+			// 环境变量无法表达 map，只能通过配置文件设置
+		default:
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("system.host", "0.0.0.0")
+	v.SetDefault("system.port", 8080)
+	v.SetDefault("system.language", "zh_CN")
+	v.SetDefault("system.shutdown_timeout", 30*time.Second)
+	v.SetDefault("system.log.level", "info")
+	v.SetDefault("system.log.format", "text")
+	v.SetDefault("system.log.output", "stdout")
+	v.SetDefault("system.log.max_size", 100)
+	v.SetDefault("system.log.max_backups", 7)
+	v.SetDefault("system.log.max_age", 30)
+
+	v.SetDefault("mysql.host", "127.0.0.1")
+	v.SetDefault("mysql.port", 3306)
+	v.SetDefault("mysql.username", "root")
+	v.SetDefault("mysql.database", "civ")
+
+	v.SetDefault("agent.address", "127.0.0.1:50051")
+	v.SetDefault("agent.balancer", "round_robin")
+	v.SetDefault("agent.health_check", true)
+	v.SetDefault("agent.circuit_breaker.failure_threshold", 5)
+	v.SetDefault("agent.circuit_breaker.open_timeout", 30*time.Second)
+	v.SetDefault("agent.heartbeat_interval", 10*time.Second)
+	v.SetDefault("agent.max_running", 100)
+	v.SetDefault("agent.timeout", 30*time.Second)
+	v.SetDefault("agent.model", "deepseek")
+	v.SetDefault("agent.prompt", "build_failure")
+
+	v.SetDefault("grpc.address", "0.0.0.0:50052")
+
+	v.SetDefault("storage.log_dir", "logs/jobs")
+
+	v.SetDefault("usage.currency", "USD")
+
+	v.SetDefault("metrics.enabled", true)
+	v.SetDefault("metrics.path", "/metrics")
+
+	v.SetDefault("trace.service_name", "civ-backend")
+	v.SetDefault("trace.endpoint", "localhost:4318")
+	v.SetDefault("trace.sample_ratio", 1.0)
 }
@@ -1,13 +1,19 @@
 package config
 
 import (
+	"civ/config/autoload"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoadAppConfig(t *testing.T) {
-	config := LoadConfig()
+	config, err := LoadConfig(Options{})
+	require.NoError(t, err)
 
 	assert.NotNil(t, config, "Config object should not be nil")
 
@@ -18,7 +24,10 @@ func TestLoadAppConfig(t *testing.T) {
 }
 
 func TestLoadMySQLConfig(t *testing.T) {
-	config := GetConfig()
+	t.Setenv("CIV_MYSQL_PASSWORD", "secret")
+
+	config, err := LoadConfig(Options{})
+	require.NoError(t, err)
 	assert.NotNil(t, config, "Config object should not be nil")
 
 	mysqlConfig := config.MySQL
@@ -31,3 +40,115 @@ func TestLoadMySQLConfig(t *testing.T) {
 
 	t.Logf("MySQL config loaded successfully")
 }
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	writeFile(t, base, "system:\n  port: 9000\n  language: en\nmysql:\n  database: base_db\n")
+	writeFile(t, filepath.Join(dir, "config.prod.yaml"), "mysql:\n  database: prod_db\n")
+
+	t.Setenv(EnvConfigFile, base)
+	t.Setenv(EnvProfile, "prod")
+	t.Setenv("CIV_SYSTEM_PORT", "9100")
+	t.Setenv("CIV_AGENT_TIMEOUT", "5s")
+
+	config, err := LoadConfig(Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "en", config.System.Language, "base file value")
+	assert.Equal(t, "prod_db", config.MySQL.Database, "profile overlay overrides base file")
+	assert.Equal(t, 9100, config.System.Port, "environment overrides files")
+	assert.Equal(t, 5*time.Second, config.Agent.Timeout)
+	assert.Equal(t, "127.0.0.1", config.MySQL.Host, "default fills unset keys")
+}
+
+func TestLoadConfigMissingExplicitFile(t *testing.T) {
+	_, err := LoadConfig(Options{File: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	config, err := LoadConfig(Options{})
+	require.NoError(t, err)
+
+	config.MySQL.Port = 70000
+	config.MySQL.Database = ""
+	config.System.Language = "fr"
+	config.Agent.Address = "not-an-address"
+
+	err = Validate(config)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Len(t, validationErr.Problems, 4)
+	assert.Contains(t, err.Error(), "mysql.port must be at most 65535")
+	assert.Contains(t, err.Error(), "mysql.database is required")
+	assert.Contains(t, err.Error(), "system.language must be one of [zh_CN, en]")
+	assert.Contains(t, err.Error(), "agent.address must be an address in host:port form")
+}
+
+func TestValidateRequiresGRPCAuth(t *testing.T) {
+	config, err := LoadConfig(Options{})
+	require.NoError(t, err)
+
+	config.GRPC.Enabled = true
+	err = Validate(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "grpc.token is required when grpc is enabled without mutual TLS")
+
+	assert.Contains(t, err.Error(), "grpc.tool_secret is required")
+
+	config.GRPC.Token = "s3cret"
+	config.GRPC.ToolSecret = "tool-s3cret"
+	require.NoError(t, Validate(config))
+
+	config.GRPC.Token = ""
+	config.GRPC.TLS = autoload.TLSConfig{Enabled: true, CAFile: "ca.pem", CertFile: "server.pem", KeyFile: "server.key"}
+	require.NoError(t, Validate(config))
+}
+
+func TestValidateAcceptsLanguagesFromMessagesDir(t *testing.T) {
+	config, err := LoadConfig(Options{})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "ja.yaml"), "10001: ユーザー {username} は存在しません\n")
+	config.System.Language = "ja"
+	err = Validate(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "system.language must be one of [zh_CN, en]")
+
+	config.System.MessagesDir = dir
+	require.NoError(t, Validate(config))
+}
+
+func TestLoadConfigRejectsInvalidFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, file, "system:\n  port: 0\n")
+
+	_, err := LoadConfig(Options{File: file})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestLoadConfigResolvesSecretReferences(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "db_password")
+	writeFile(t, secretFile, "from-file\n")
+	file := filepath.Join(dir, "config.yaml")
+	writeFile(t, file, "mysql:\n  password: file://"+secretFile+"\n  username: env:CIV_TEST_DB_USER\n")
+	t.Setenv("CIV_TEST_DB_USER", "civ")
+
+	config, err := LoadConfig(Options{File: file})
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", config.MySQL.Password)
+	assert.Equal(t, "civ", config.MySQL.Username)
+
+	writeFile(t, file, "mysql:\n  password: env:CIV_TEST_UNSET_PASSWORD\n")
+	_, err = LoadConfig(Options{File: file})
+	assert.ErrorContains(t, err, "mysql.password")
+}
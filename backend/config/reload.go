@@ -0,0 +1,182 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const reloadDebounce = 250 * time.Millisecond
+
+// Subscriber is called after a reloaded configuration has been applied.
+// old and new are immutable snapshots and must not be modified.
+type Subscriber func(old, new *Config)
+
+var (
+	subMu       sync.Mutex
+	subscribers []Subscriber
+
+	pendingMu sync.Mutex
+	pending   []string
+)
+
+// Subscribe registers fn to be notified of every applied reload.
+func Subscribe(fn Subscriber) {
+	subMu.Lock()
+	defer subMu.Unlock()
+	subscribers = append(subscribers, fn)
+}
+
+// Pending returns the keys changed on disk that only take effect after a restart.
+func Pending() []string {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	return append([]string(nil), pending...)
+}
+
+// Reload re-reads the configuration with the options used at startup, validates it
+// and atomically swaps the snapshot returned by GetConfig. Fields tagged
+// reload:"restart" keep their running values and are reported by Pending.
+// On error the current snapshot stays in place.
+func Reload() error {
+	current := appConfig.Load()
+	if current == nil {
+		return errors.New("config is not loaded")
+	}
+
+	next, _, err := load(loadedOptions)
+	if err != nil {
+		return err
+	}
+	keys := keepRestartFields(reflect.ValueOf(current).Elem(), reflect.ValueOf(next).Elem(), "", false)
+
+	pendingMu.Lock()
+	pending = keys
+	pendingMu.Unlock()
+	if len(keys) > 0 {
+		slog.Warn("config changes require restart", "keys", keys)
+	}
+
+	if reflect.DeepEqual(current, next) {
+		return nil
+	}
+	appConfig.Store(next)
+	slog.Info("config reloaded")
+
+	subMu.Lock()
+	subs := append([]Subscriber(nil), subscribers...)
+	subMu.Unlock()
+	for _, fn := range subs {
+		fn(current, next)
+	}
+	return nil
+}
+
+// Watch reloads the configuration whenever the base file or profile overlay changes,
+// until ctx is cancelled. The containing directories are watched so that editors
+// replacing the file and Kubernetes ConfigMap symlink swaps are both noticed.
+func Watch(ctx context.Context) error {
+	if len(watchFiles) == 0 {
+		return errors.New("config is not loaded")
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	dirs := make(map[string]bool)
+	for _, file := range watchFiles {
+		dir := filepath.Dir(filepath.Clean(file))
+		if dirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			slog.Warn("cannot watch config directory", "dir", dir, "error", err)
+			continue
+		}
+		dirs[dir] = true
+	}
+
+	go func() {
+		defer watcher.Close()
+		var timer *time.Timer
+		for {
+			select {
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !relevant(event) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(reloadDebounce, func() {
+					if err := Reload(); err != nil {
+						slog.Error("config reload rejected, keeping current config", "error", err)
+					}
+				})
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("config watcher error", "error", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func relevant(event fsnotify.Event) bool {
+	name := filepath.Base(event.Name)
+	if strings.HasPrefix(name, "..") {
+		// Kubernetes 通过 ..data 软链接整体替换 ConfigMap 目录
+		return true
+	}
+	for _, file := range watchFiles {
+		if filepath.Clean(event.Name) == filepath.Clean(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepRestartFields copies restart-only fields from current into next and returns
+// the dotted keys whose values differed.
+func keepRestartFields(current, next reflect.Value, prefix string, restart bool) []string {
+	var keys []string
+	t := current.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := tagName(field)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		fieldRestart := restart || field.Tag.Get("reload") == "restart"
+
+		cur, nxt := current.Field(i), next.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			keys = append(keys, keepRestartFields(cur, nxt, key+".", fieldRestart)...)
+			continue
+		}
+		if fieldRestart && !reflect.DeepEqual(cur.Interface(), nxt.Interface()) {
+			keys = append(keys, key)
+			nxt.Set(cur)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadAppliesSafeKeysAndReportsPending(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, file, "system:\n  port: 8080\n  language: zh_CN\n")
+
+	previous, previousOptions := appConfig.Load(), loadedOptions
+	t.Cleanup(func() {
+		appConfig.Store(previous)
+		loadedOptions = previousOptions
+		subscribers = nil
+		pending = nil
+	})
+
+	initial, err := LoadConfig(Options{File: file})
+	require.NoError(t, err)
+	appConfig.Store(initial)
+	loadedOptions = Options{File: file}
+
+	var notified *Config
+	Subscribe(func(old, next *Config) { notified = next })
+
+	writeFile(t, file, "system:\n  port: 9090\n  language: en\n  log:\n    level: debug\n")
+	require.NoError(t, Reload())
+
+	current := appConfig.Load()
+	assert.Equal(t, "en", current.System.Language)
+	assert.Equal(t, "debug", current.System.Log.Level)
+	assert.Equal(t, 8080, current.System.Port, "restart-only key must keep its running value")
+	assert.Equal(t, []string{"system.port"}, Pending())
+	assert.Same(t, current, notified)
+
+	writeFile(t, file, "system:\n  language: fr\n")
+	assert.Error(t, Reload())
+	assert.Same(t, current, appConfig.Load(), "invalid config must not replace the snapshot")
+}
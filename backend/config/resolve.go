@@ -0,0 +1,112 @@
+package config
+
+import (
+	"civ/config/secret"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const secretsTimeout = 30 * time.Second
+
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = make(map[string]secret.Provider)
+)
+
+// RegisterSecretProvider makes p available for config values using scheme, e.g.
+// "awssm" for values like "awssm://prod/civ#password". It applies to every later
+// load and reload.
+func RegisterSecretProvider(scheme string, p secret.Provider) {
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+	secretProviders[scheme] = p
+}
+
+// resolveSecrets replaces every string value in config that is a secret reference
+// (file://, env:, vault:// or a registered scheme) with the secret it points to.
+// The secrets section is resolved first so the Vault address and token may
+// themselves be file or env references. All failures are reported together.
+func resolveSecrets(config *Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+	defer cancel()
+
+	r := secret.NewResolver()
+	secretProvidersMu.RLock()
+	for scheme, p := range secretProviders {
+		r.Register(scheme, p)
+	}
+	secretProvidersMu.RUnlock()
+
+	var errs []error
+	root := reflect.ValueOf(config).Elem()
+	secrets := root.FieldByName("Secrets")
+	resolveValue(ctx, r, secrets, "secrets", &errs)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	vault := config.Secrets.Vault
+	if vault.Address != "" {
+		r.Register("vault", secret.NewVaultProvider(vault.Address, vault.Token, vault.Namespace, vault.Timeout))
+	}
+
+	t := root.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Name == "Secrets" {
+			continue
+		}
+		resolveValue(ctx, r, root.Field(i), tagName(t.Field(i)), &errs)
+	}
+	return errors.Join(errs...)
+}
+
+func resolveValue(ctx context.Context, r *secret.Resolver, v reflect.Value, key string, errs *[]error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if name := tagName(t.Field(i)); name != "" {
+				resolveValue(ctx, r, v.Field(i), key+"."+name, errs)
+			}
+		}
+	case reflect.String:
+		if !r.IsReference(v.String()) {
+			return
+		}
+		s, err := r.Resolve(ctx, v.String())
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+			return
+		}
+		v.SetString(s)
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if !r.IsReference(iter.Value().String()) {
+				continue
+			}
+			s, err := r.Resolve(ctx, iter.Value().String())
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%s.%v: %w", key, iter.Key(), err))
+				continue
+			}
+			v.SetMapIndex(iter.Key(), reflect.ValueOf(s).Convert(v.Type().Elem()))
+		}
+	}
+}
+
+func tagName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
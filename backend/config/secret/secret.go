@@ -0,0 +1,106 @@
+// Package secret resolves secret references used as config values, such as
+// "file:///run/secrets/db", "env:DB_PASSWORD" or "vault://secret/data/civ#password".
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Provider resolves references of one URL scheme to their secret value.
+type Provider interface {
+	Resolve(ctx context.Context, ref *url.URL) (string, error)
+}
+
+// ProviderFunc adapts a function to Provider.
+type ProviderFunc func(ctx context.Context, ref *url.URL) (string, error)
+
+func (f ProviderFunc) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	return f(ctx, ref)
+}
+
+// Resolver dispatches references to the provider registered for their scheme.
+type Resolver struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+// NewResolver returns a Resolver with the built-in "file" and "env" providers.
+func NewResolver() *Resolver {
+	return &Resolver{providers: map[string]Provider{
+		"file": ProviderFunc(resolveFile),
+		"env":  ProviderFunc(resolveEnv),
+	}}
+}
+
+// Register adds or replaces the provider for scheme.
+func (r *Resolver) Register(scheme string, p Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[scheme] = p
+}
+
+// IsReference reports whether value uses a scheme with a registered provider.
+func (r *Resolver) IsReference(value string) bool {
+	_, ok := r.provider(value)
+	return ok
+}
+
+// Resolve returns the secret referenced by value. Values that are not references
+// are returned unchanged.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	p, ok := r.provider(value)
+	if !ok {
+		return value, nil
+	}
+	ref, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid secret reference: %w", err)
+	}
+	secret, err := p.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s secret: %w", ref.Scheme, err)
+	}
+	return secret, nil
+}
+
+func (r *Resolver) provider(value string) (Provider, bool) {
+	scheme, _, ok := strings.Cut(value, ":")
+	if !ok || scheme == "" {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[scheme]
+	return p, ok
+}
+
+// resolveFile reads file:///path, trimming the trailing newline that secret files usually carry.
+func resolveFile(_ context.Context, ref *url.URL) (string, error) {
+	if ref.Path == "" {
+		return "", errors.New("file reference must be an absolute path like file:///run/secrets/name")
+	}
+	b, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// resolveEnv reads env:NAME; an unset variable is an error so that typos do not become empty secrets.
+func resolveEnv(_ context.Context, ref *url.URL) (string, error) {
+	name := ref.Opaque
+	if name == "" {
+		return "", errors.New("env reference must look like env:NAME")
+	}
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return v, nil
+}
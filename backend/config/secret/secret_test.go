@@ -0,0 +1,78 @@
+package secret
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFileAndEnv(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "db")
+	require.NoError(t, os.WriteFile(file, []byte("s3cret\n"), 0o600))
+	t.Setenv("CIV_TEST_SECRET", "from-env")
+
+	r := NewResolver()
+
+	v, err := r.Resolve(context.Background(), "file://"+file)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", v)
+
+	v, err = r.Resolve(context.Background(), "env:CIV_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", v)
+
+	v, err = r.Resolve(context.Background(), "plain-password")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-password", v, "non-references are returned unchanged")
+
+	_, err = r.Resolve(context.Background(), "env:CIV_TEST_UNSET")
+	assert.Error(t, err)
+}
+
+func TestVaultProvider(t *testing.T) {
+	requests := 0
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("X-Vault-Token") != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/civ/mysql":
+			w.Write([]byte(`{"data":{"data":{"password":"kv2-pass"},"metadata":{"version":3}}}`))
+		case "/v1/kv/civ/llm":
+			w.Write([]byte(`{"data":{"api_key":"kv1-key"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	r := NewResolver()
+	r.Register("vault", NewVaultProvider(vault.URL, "root-token", "", 0))
+	ctx := context.Background()
+
+	v, err := r.Resolve(ctx, "vault://secret/data/civ/mysql#password")
+	require.NoError(t, err)
+	assert.Equal(t, "kv2-pass", v)
+
+	_, err = r.Resolve(ctx, "vault://secret/data/civ/mysql#password")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests, "repeated paths are served from cache")
+
+	v, err = r.Resolve(ctx, "vault://kv/civ/llm#api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-key", v)
+
+	_, err = r.Resolve(ctx, "vault://secret/data/civ/missing#password")
+	assert.Error(t, err)
+
+	_, err = r.Resolve(ctx, "vault://secret/data/civ/mysql#username")
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultVaultTimeout = 5 * time.Second
+
+// VaultProvider reads secrets over the Vault HTTP API. References look like
+// vault://<mount>/<path>#<key>, e.g. vault://secret/data/civ/mysql#password.
+// Both KV v1 and KV v2 response layouts are supported. Responses are cached per
+// path for the lifetime of the provider, so one load does not repeat requests.
+type VaultProvider struct {
+	address   string
+	token     string
+	namespace string
+	client    *http.Client
+
+	mu    sync.Mutex
+	cache map[string]map[string]any
+}
+
+func NewVaultProvider(address, token, namespace string, timeout time.Duration) *VaultProvider {
+	if timeout <= 0 {
+		timeout = defaultVaultTimeout
+	}
+	return &VaultProvider{
+		address:   strings.TrimRight(address, "/"),
+		token:     token,
+		namespace: namespace,
+		client:    &http.Client{Timeout: timeout},
+		cache:     make(map[string]map[string]any),
+	}
+}
+
+func (p *VaultProvider) Resolve(ctx context.Context, ref *url.URL) (string, error) {
+	path := strings.Trim(ref.Host+ref.Path, "/")
+	if path == "" || ref.Fragment == "" {
+		return "", errors.New("vault reference must look like vault://mount/path#key")
+	}
+
+	data, err := p.read(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	v, ok := data[ref.Fragment]
+	if !ok {
+		return "", fmt.Errorf("key %q not found at %s", ref.Fragment, path)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q at %s is not a string", ref.Fragment, path)
+	}
+	return s, nil
+}
+
+func (p *VaultProvider) read(ctx context.Context, path string) (map[string]any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if data, ok := p.cache[path]; ok {
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %s for %s", resp.Status, path)
+	}
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode vault response: %w", err)
+	}
+	data := body.Data
+	// KV v2 将实际数据包在 data.data 中，并附带 metadata
+	if inner, ok := data["data"].(map[string]any); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = inner
+		}
+	}
+	p.cache[path] = data
+	return data, nil
+}
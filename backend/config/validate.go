@@ -0,0 +1,159 @@
+package config
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/logger"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidationError lists every problem found in a configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// 错误信息中使用配置文件里的键名，如 mysql.port
+	v.RegisterTagNameFunc(tagName)
+	_ = v.RegisterValidation("grpc_target", validGRPCTarget)
+	v.RegisterStructValidation(validGRPCAuth, autoload.GRPCConfig{})
+	v.RegisterStructValidation(validLanguage, autoload.SystemConfig{})
+	return v
+}
+
+// builtinLanguages 内置错误文案的语言，见 internal/pkg/errors/locales
+var builtinLanguages = []string{"zh_CN", "en"}
+
+// validLanguage 要求默认语言是内置语言，或 messages_dir 中有对应的 <语言>.yaml|yml|json 文案文件
+func validLanguage(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(autoload.SystemConfig)
+	languages := append(slices.Clone(builtinLanguages), messageLanguages(cfg.MessagesDir)...)
+	if !slices.Contains(languages, cfg.Language) {
+		sl.ReportError(cfg.Language, "language", "Language", "language", strings.Join(languages, " "))
+	}
+}
+
+// messageLanguages 按文件名列出 dir 中文案文件的语言，与错误文案目录的加载规则一致；
+// 目录不存在或不可读时返回空，由 messages_dir 自身的校验报告
+func messageLanguages(dir string) []string {
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var languages []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		if language := strings.TrimSuffix(entry.Name(), ext); !slices.Contains(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// validGRPCAuth 要求开启的 gRPC 服务配置 token 或 mTLS，否则任何能访问端口的主机都可以注册代理地址，
+// 后端会携带代理令牌连接该地址并发送任务日志
+func validGRPCAuth(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(autoload.GRPCConfig)
+	if cfg.Enabled && cfg.Token == "" && !(cfg.TLS.Enabled && cfg.TLS.CAFile != "") {
+		sl.ReportError(cfg.Token, "token", "Token", "grpc_auth", "")
+	}
+}
+
+// validGRPCTarget 接受空值、host:port 和 dns:///host:port（dns://resolver/host:port）
+func validGRPCTarget(fl validator.FieldLevel) bool {
+	target := fl.Field().String()
+	if target == "" {
+		return true
+	}
+	if rest, ok := strings.CutPrefix(target, "dns://"); ok {
+		_, target, ok = strings.Cut(rest, "/")
+		if !ok {
+			return false
+		}
+	}
+	host, port, err := net.SplitHostPort(target)
+	if err != nil || host == "" {
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// Validate checks the configuration against the validate tags on the autoload structs
+// and reports all problems at once as a *ValidationError.
+func Validate(config *Config) error {
+	err := validate.Struct(config)
+	if err == nil {
+		return nil
+	}
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	problems := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		problems = append(problems, describe(fe))
+	}
+	return &ValidationError{Problems: problems}
+}
+
+func describe(fe validator.FieldError) string {
+	key := fe.Namespace()
+	if i := strings.Index(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+
+	var rule string
+	switch fe.Tag() {
+	case "required", "required_if":
+		rule = "is required"
+	case "min":
+		rule = "must be at least " + fe.Param()
+	case "max":
+		rule = "must be at most " + fe.Param()
+	case "oneof", "language":
+		rule = "must be one of [" + strings.ReplaceAll(fe.Param(), " ", ", ") + "]"
+	case "hostname_port":
+		rule = "must be an address in host:port form"
+	case "grpc_auth":
+		rule = "is required when grpc is enabled without mutual TLS (tls.enabled and tls.ca_file)"
+	case "grpc_target":
+		rule = "must be an address in host:port form or dns:///host:port"
+	case "required_without":
+		rule = "is required unless " + fe.Param() + " is set"
+	case "hostname|ip":
+		rule = "must be a hostname or IP address"
+	case "startswith":
+		rule = fmt.Sprintf("must start with %q", fe.Param())
+	case "url":
+		rule = "must be a valid URL"
+	default:
+		rule = fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+	if logger.IsSecretKey(key) {
+		return fmt.Sprintf("%s %s", key, rule)
+	}
+	return fmt.Sprintf("%s %s (got %v)", key, rule, fe.Value())
+}
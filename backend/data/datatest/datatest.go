@@ -0,0 +1,35 @@
+// Package datatest 为测试提供迁移好的内存数据库
+package datatest
+
+import (
+	"civ/data"
+	"civ/data/migrate"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Use 将 data.DB 指向迁移好的内存 SQLite 数据库，测试结束时恢复
+func Use(t testing.TB) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	// 每个连接都是独立的内存数据库，限制为单连接保证看到同一份数据
+	sqlDB.SetMaxOpenConns(1)
+	if _, err := migrate.Up(db); err != nil {
+		t.Fatalf("migrate test db: %v", err)
+	}
+
+	previous := data.DB
+	data.DB = db
+	t.Cleanup(func() { data.DB = previous })
+	return db
+}
@@ -0,0 +1,119 @@
+// Package migrate applies the versioned schema migrations in migrations.go and
+// records them in the schema_migrations table.
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Migration 一次结构变更，ID 按时间顺序排列且发布后不可修改
+type Migration struct {
+	ID   string
+	Up   func(tx *gorm.DB) error
+	Down func(tx *gorm.DB) error
+}
+
+// Status 迁移的执行状态
+type Status struct {
+	ID        string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:128"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Up 依次执行所有未执行的迁移，返回本次执行的迁移 ID
+func Up(db *gorm.DB) ([]string, error) {
+	applied, err := appliedSet(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for _, m := range migrations {
+		if _, ok := applied[m.ID]; ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{ID: m.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %s up: %w", m.ID, err)
+		}
+		done = append(done, m.ID)
+	}
+	return done, nil
+}
+
+// Down 按倒序回滚最近执行的 steps 个迁移，返回被回滚的迁移 ID
+func Down(db *gorm.DB, steps int) ([]string, error) {
+	applied, err := appliedSet(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.ID]; !ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{ID: m.ID}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %s down: %w", m.ID, err)
+		}
+		done = append(done, m.ID)
+	}
+	return done, nil
+}
+
+// List 返回所有迁移及其执行状态
+func List(db *gorm.DB) ([]Status, error) {
+	applied, err := appliedSet(db)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		s := Status{ID: m.ID}
+		if at, ok := applied[m.ID]; ok {
+			s.Applied = true
+			s.AppliedAt = &at
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+func appliedSet(db *gorm.DB) (map[string]time.Time, error) {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, err
+	}
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[string]time.Time, len(rows))
+	for _, r := range rows {
+		applied[r.ID] = r.AppliedAt
+	}
+	return applied, nil
+}
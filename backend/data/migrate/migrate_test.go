@@ -0,0 +1,92 @@
+package migrate
+
+import (
+	"civ/internal/model"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// 每个连接都是独立的内存数据库，限制为单连接保证看到同一份数据
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+func TestUpDownStatus(t *testing.T) {
+	db := openTestDB(t)
+
+	done, err := Up(db)
+	require.NoError(t, err)
+	assert.Len(t, done, len(migrations))
+	assert.True(t, db.Migrator().HasTable("analyses"))
+
+	done, err = Up(db)
+	require.NoError(t, err)
+	assert.Empty(t, done, "Up should be idempotent")
+
+	done, err = Down(db, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{migrations[len(migrations)-1].ID}, done)
+
+	statuses, err := List(db)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(migrations))
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[len(statuses)-1].Applied)
+}
+
+func columnNames(t *testing.T, db *gorm.DB, table string) []string {
+	t.Helper()
+	columns, err := db.Migrator().ColumnTypes(table)
+	require.NoError(t, err)
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+func TestDownUpIsReproducible(t *testing.T) {
+	db := openTestDB(t)
+	_, err := Up(db)
+	require.NoError(t, err)
+	want := columnNames(t, db, "analyses")
+	assert.Contains(t, want, "prompt_name")
+
+	_, err = Down(db, 3)
+	require.NoError(t, err)
+	assert.NotContains(t, columnNames(t, db, "analyses"), "prompt_name", "down of the prompt migration drops its columns")
+
+	_, err = Up(db)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, want, columnNames(t, db, "analyses"))
+}
+
+func TestSchemaCoversModels(t *testing.T) {
+	db := openTestDB(t)
+	_, err := Up(db)
+	require.NoError(t, err)
+	for _, m := range []any{
+		&model.User{}, &model.APIToken{}, &model.Job{}, &model.Analysis{},
+		&model.PromptTemplate{}, &model.PromptVersion{}, &model.AnalysisUsage{}, &model.AnalysisFeedback{},
+	} {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(m))
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			assert.True(t, db.Migrator().HasColumn(m, field.DBName), "%s.%s needs a migration", stmt.Schema.Table, field.DBName)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"gorm.io/gorm"
+)
+
+// migrations 按执行顺序排列，只能在末尾追加。表结构取自 schema.go 中各迁移自己的快照
+var migrations = []Migration{
+	{
+		ID: "202610190001_create_users_and_tokens",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&user0001{}, &apiToken0001{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&apiToken0001{}, &user0001{})
+		},
+	},
+	{
+		ID: "202610190002_create_jobs_and_analyses",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&job0002{}, &analysis0002{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&analysis0002{}, &job0002{})
+		},
+	},
+	{
+		ID: "202610190003_create_prompt_templates",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&promptTemplate0003{}, &promptVersion0003{}); err != nil {
+				return err
+			}
+			return addColumns(tx, &analysisPrompt0003{}, "PromptName", "PromptVersion")
+		},
+		Down: func(tx *gorm.DB) error {
+			for _, column := range []string{"PromptName", "PromptVersion"} {
+				if err := tx.Migrator().DropColumn(&analysisPrompt0003{}, column); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&promptVersion0003{}, &promptTemplate0003{})
+		},
+	},
+	{
+		ID: "202610190004_create_analysis_usages",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&analysisUsage0004{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&analysisUsage0004{})
+		},
+	},
+	{
+		ID: "202610190005_create_analysis_feedbacks",
+		Up: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&analysisFeedback0005{})
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&analysisFeedback0005{})
+		},
+	},
+}
+
+// addColumns 为已有表添加 fields 对应的列，已存在的列跳过
+func addColumns(tx *gorm.DB, snapshot any, fields ...string) error {
+	for _, field := range fields {
+		if tx.Migrator().HasColumn(snapshot, field) {
+			continue
+		}
+		if err := tx.Migrator().AddColumn(snapshot, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package migrate
+
+import "time"
+
+// 每个迁移使用自己的表结构快照，而不是 internal/model 中随业务变化的模型，
+// 保证任何时候在空库上执行的结果都与迁移发布时一致。快照发布后不可修改，
+// 结构变更通过新的迁移和新的快照完成。
+
+// 202610190001_create_users_and_tokens
+
+type user0001 struct {
+	ID           uint   `gorm:"primaryKey"`
+	Username     string `gorm:"size:64;uniqueIndex;not null"`
+	Email        string `gorm:"size:255"`
+	PasswordHash string `gorm:"size:255;not null"`
+	Role         string `gorm:"size:16;not null;default:member"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (user0001) TableName() string { return "users" }
+
+type apiToken0001 struct {
+	ID         uint   `gorm:"primaryKey"`
+	UserID     uint   `gorm:"index;not null"`
+	Name       string `gorm:"size:64"`
+	Prefix     string `gorm:"size:16"`
+	TokenHash  string `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (apiToken0001) TableName() string { return "api_tokens" }
+
+// 202610190002_create_jobs_and_analyses
+
+type job0002 struct {
+	ID         uint   `gorm:"primaryKey"`
+	Project    string `gorm:"size:128;not null;uniqueIndex:idx_jobs_project_external"`
+	ExternalID string `gorm:"size:128;not null;uniqueIndex:idx_jobs_project_external"`
+	Name       string `gorm:"size:255;not null"`
+	Branch     string `gorm:"size:255;index"`
+	CommitSHA  string `gorm:"size:64"`
+	Status     string `gorm:"size:32;index"`
+	LogPath    string `gorm:"size:512"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time `gorm:"index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (job0002) TableName() string { return "jobs" }
+
+type analysis0002 struct {
+	ID          uint   `gorm:"primaryKey"`
+	JobID       uint   `gorm:"index;not null"`
+	Status      string `gorm:"size:16;index;not null"`
+	Model       string `gorm:"size:64"`
+	Summary     string `gorm:"type:text"`
+	RootCause   string `gorm:"type:text"`
+	Suggestion  string `gorm:"type:text"`
+	Category    string `gorm:"size:64;index"`
+	Error       string `gorm:"type:text"`
+	RequestedBy *uint
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (analysis0002) TableName() string { return "analyses" }
+
+// 202610190003_create_prompt_templates
+
+type promptTemplate0003 struct {
+	ID            uint   `gorm:"primaryKey"`
+	Name          string `gorm:"size:64;not null;uniqueIndex:idx_prompt_templates_name_project"`
+	Project       string `gorm:"size:128;not null;default:'';uniqueIndex:idx_prompt_templates_name_project"`
+	Description   string `gorm:"size:512"`
+	ActiveVersion int    `gorm:"not null;default:0"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (promptTemplate0003) TableName() string { return "prompt_templates" }
+
+type promptVersion0003 struct {
+	ID         uint   `gorm:"primaryKey"`
+	TemplateID uint   `gorm:"not null;uniqueIndex:idx_prompt_versions_template_version"`
+	Version    int    `gorm:"not null;uniqueIndex:idx_prompt_versions_template_version"`
+	Body       string `gorm:"type:text;not null"`
+	Variables  string `gorm:"type:text"`
+	Comment    string `gorm:"size:255"`
+	CreatedAt  time.Time
+}
+
+func (promptVersion0003) TableName() string { return "prompt_versions" }
+
+// analysisPrompt0003 analyses 表新增的列
+type analysisPrompt0003 struct {
+	ID            uint   `gorm:"primaryKey"`
+	PromptName    string `gorm:"size:64"`
+	PromptVersion int
+}
+
+func (analysisPrompt0003) TableName() string { return "analyses" }
+
+// 202610190004_create_analysis_usages
+
+type analysisUsage0004 struct {
+	ID               uint    `gorm:"primaryKey"`
+	AnalysisID       uint    `gorm:"uniqueIndex;not null"`
+	Project          string  `gorm:"size:128;index;not null"`
+	RequestedBy      *uint   `gorm:"index"`
+	Model            string  `gorm:"size:64;not null"`
+	PromptTokens     int64   `gorm:"not null"`
+	CompletionTokens int64   `gorm:"not null"`
+	LatencyMs        int64   `gorm:"not null"`
+	Cost             float64 `gorm:"not null"`
+	Day              string  `gorm:"size:10;index;not null"`
+	Month            string  `gorm:"size:7;index;not null"`
+	CreatedAt        time.Time
+}
+
+func (analysisUsage0004) TableName() string { return "analysis_usages" }
+
+// 202610190005_create_analysis_feedbacks
+
+type analysisFeedback0005 struct {
+	ID         uint   `gorm:"primaryKey"`
+	AnalysisID uint   `gorm:"uniqueIndex;not null"`
+	Rating     string `gorm:"size:16;index;not null"`
+	RootCause  string `gorm:"type:text"`
+	FixCommit  string `gorm:"size:64"`
+	Comment    string `gorm:"type:text"`
+	RatedBy    *uint
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (analysisFeedback0005) TableName() string { return "analysis_feedbacks" }
@@ -2,11 +2,16 @@ package data
 
 import (
 	"civ/config"
+	"civ/internal/pkg/logger"
+	"civ/internal/pkg/metrics"
+	"civ/internal/pkg/tracing"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
@@ -21,11 +26,27 @@ func IniDB() (*gorm.DB, error) {
 		cfg.MySQL.Database,
 	)
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		// 唯一索引冲突转换为 gorm.ErrDuplicatedKey，由业务代码翻译为具体的错误码
+		TranslateError: true,
+		Logger: gormlogger.New(logger.StdLogger(slog.LevelInfo), gormlogger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  gormlogger.Info,
+			IgnoreRecordNotFoundError: true,
+		}),
 	})
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Metrics.Enabled {
+		if err := db.Use(metrics.NewGormPlugin(cfg.MySQL.Database)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Trace.Enabled {
+		if err := db.Use(tracing.NewGormPlugin(cfg.MySQL.Database)); err != nil {
+			return nil, err
+		}
+	}
 	DB = db
 	return DB, nil
 }
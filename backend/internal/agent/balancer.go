@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/balancer/leastrequest"
+	"google.golang.org/grpc/balancer/roundrobin"
+	_ "google.golang.org/grpc/health" // 注册客户端健康检查
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+const (
+	BalancerRoundRobin  = "round_robin"
+	BalancerLeastLoaded = "least_loaded"
+
+	staticScheme = "civ-agents"
+)
+
+// dialTarget 返回 grpc.NewClient 的目标地址：Endpoints 通过手动 resolver 提供；
+// Address 由 gRPC 的 DNS resolver 解析，解析出的每个地址都参与负载均衡
+func dialTarget(cfg autoload.AgentConfig) (string, []grpc.DialOption) {
+	if len(cfg.Endpoints) == 0 {
+		return cfg.Address, nil
+	}
+	r := manual.NewBuilderWithScheme(staticScheme)
+	endpoints := make([]resolver.Endpoint, 0, len(cfg.Endpoints))
+	for _, addr := range cfg.Endpoints {
+		endpoints = append(endpoints, resolver.Endpoint{Addresses: []resolver.Address{{Addr: addr}}})
+	}
+	r.InitialState(resolver.State{Endpoints: endpoints})
+	return staticScheme + ":///agents", []grpc.DialOption{grpc.WithResolvers(r)}
+}
+
+// serverName 返回用于 TLS 校验的 host:port，取第一个静态地址或去掉 dns:// 前缀的 Address
+func serverName(cfg autoload.AgentConfig) string {
+	if len(cfg.Endpoints) > 0 {
+		return cfg.Endpoints[0]
+	}
+	if rest, ok := strings.CutPrefix(cfg.Address, "dns://"); ok {
+		_, hostPort, _ := strings.Cut(rest, "/")
+		return hostPort
+	}
+	return cfg.Address
+}
+
+// serviceConfig 生成负载均衡和健康检查的 gRPC service config
+func serviceConfig(cfg autoload.AgentConfig) string {
+	policy := map[string]any{roundrobin.Name: struct{}{}}
+	if cfg.Balancer == BalancerLeastLoaded {
+		policy = map[string]any{leastrequest.Name: map[string]any{"choiceCount": 2}}
+	}
+	sc := map[string]any{"loadBalancingConfig": []any{policy}}
+	if cfg.HealthCheck {
+		// 空服务名表示代理整体的健康状态，不健康的代理不会被选中
+		sc["healthCheckConfig"] = map[string]any{"serviceName": ""}
+	}
+	b, _ := json.Marshal(sc)
+	return string(b)
+}
@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpctest"
+	hello "civ/proto"
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type countingGreeter struct {
+	hello.UnimplementedGreeterServer
+	calls atomic.Int32
+}
+
+func (g *countingGreeter) SayHello(context.Context, *hello.HelloRequest) (*hello.HelloReply, error) {
+	g.calls.Add(1)
+	return &hello.HelloReply{}, nil
+}
+
+func startAgent(t *testing.T) (string, *countingGreeter, *health.Server) {
+	t.Helper()
+	greeter := &countingGreeter{}
+	hs := health.NewServer()
+	addr := grpctest.Serve(t, func(s *grpc.Server) {
+		hello.RegisterGreeterServer(s, greeter)
+		grpc_health_v1.RegisterHealthServer(s, hs)
+	})
+	return addr, greeter, hs
+}
+
+func TestClientBalancesAndEjectsUnhealthyAgents(t *testing.T) {
+	addr1, agent1, health1 := startAgent(t)
+	addr2, agent2, _ := startAgent(t)
+	client, err := NewClient(autoload.AgentConfig{
+		Endpoints:   []string{addr1, addr2},
+		Balancer:    BalancerRoundRobin,
+		HealthCheck: true,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	require.Eventually(t, func() bool {
+		_, err := client.SayHello(ctx, "civ", 1)
+		return err == nil && agent1.calls.Load() > 0 && agent2.calls.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond, "calls are spread over both agents")
+
+	health1.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	require.Eventually(t, func() bool {
+		before := agent1.calls.Load()
+		for i := 0; i < 10; i++ {
+			if _, err := client.SayHello(ctx, "civ", 1); err != nil {
+				return false
+			}
+		}
+		return agent1.calls.Load() == before
+	}, 5*time.Second, 50*time.Millisecond, "the unhealthy agent is ejected")
+}
+
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(autoload.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	b.now = func() time.Time { return now }
+	down := status.Error(codes.Unavailable, "no healthy upstream")
+
+	b.record(status.Error(codes.InvalidArgument, "bad"))
+	b.record(down)
+	assert.True(t, b.allow(), "one failure stays closed")
+	b.record(down)
+	assert.False(t, b.allow(), "opens after the threshold")
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow(), "one probe is let through after the timeout")
+	assert.False(t, b.allow(), "other calls fail fast while probing")
+	b.record(down)
+	assert.False(t, b.allow(), "a failed probe reopens")
+
+	now = now.Add(time.Minute)
+	require.True(t, b.allow())
+	b.record(nil)
+	assert.True(t, b.allow())
+	assert.True(t, b.allow())
+}
+
+func TestClientFailsFastWhenAllAgentsAreDown(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	client, err := NewClient(autoload.AgentConfig{
+		Endpoints:      []string{addr},
+		Balancer:       BalancerLeastLoaded,
+		Timeout:        time.Second,
+		CircuitBreaker: autoload.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.SayHello(context.Background(), "civ", 1)
+	assert.ErrorIs(t, err, errors.NewBusinessError(errors.AgentUnavailable))
+	_, err = client.SayHello(context.Background(), "civ", 1)
+	assert.ErrorIs(t, err, errors.NewBusinessError(errors.AgentCircuitOpen))
+}
@@ -0,0 +1,118 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/errors"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// breaker 熔断器：连续 threshold 次调用因代理不可用失败后打开，openTimeout 内直接返回
+// AgentCircuitOpen；之后放行一次探测调用，成功则关闭，失败则重新打开
+type breaker struct {
+	mu          sync.Mutex
+	state       breakerState
+	failures    int
+	openedAt    time.Time
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+}
+
+func newBreaker(cfg autoload.CircuitBreakerConfig) *breaker {
+	b := &breaker{now: time.Now}
+	b.configure(cfg)
+	return b
+}
+
+// configure 调整阈值和熔断时长，可在运行时调用
+func (b *breaker) configure(cfg autoload.CircuitBreakerConfig) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.threshold = cfg.FailureThreshold
+	b.openTimeout = cfg.OpenTimeout
+}
+
+// allow 判断是否放行本次调用
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// 探测调用进行中，其余调用继续快速失败
+		return false
+	default:
+		return true
+	}
+}
+
+// record 记录调用结果，只有表示代理不可用的错误计入失败
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !agentDown(err) {
+		if b.state != breakerClosed {
+			slog.Info("agent circuit breaker closed")
+		}
+		b.state, b.failures = breakerClosed, 0
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+		if b.state != breakerOpen {
+			slog.Warn("agent circuit breaker opened", "failures", b.failures, "open_timeout", b.openTimeout)
+		}
+		b.state, b.openedAt = breakerOpen, b.now()
+	}
+}
+
+func agentDown(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func (b *breaker) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return errors.NewBusinessError(errors.AgentCircuitOpen)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
+
+// streamInterceptor 只按建立流的结果计数
+func (b *breaker) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !b.allow() {
+			return nil, errors.NewBusinessError(errors.AgentCircuitOpen)
+		}
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		b.record(err)
+		return stream, err
+	}
+}
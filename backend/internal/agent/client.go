@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/grpcerr"
+	"civ/internal/pkg/metrics"
+	"civ/internal/pkg/tlsconfig"
+	hello "civ/proto"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// DefaultClient 进程级的代理客户端，由 InitClient 初始化
+var DefaultClient *Client
+
+// InitClient 创建代理客户端并保存为 DefaultClient
+func InitClient(cfg autoload.AgentConfig) (*Client, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	DefaultClient = client
+	return DefaultClient, nil
+}
+
+// Client 封装与 Python 智能代理之间的 gRPC 连接
+type Client struct {
+	conn     *grpc.ClientConn
+	greeter  hello.GreeterClient
+	analyzer analyzer.AnalyzerClient
+	timeout  atomic.Int64
+	token    *tokenCredentials
+	breaker  *breaker
+}
+
+// NewClient 根据代理配置创建 gRPC 客户端，连接在首次调用时建立
+//
+// 每次调用都会创建客户端 span，并通过 metadata 中的 traceparent 头把 trace 上下文传给代理。
+// 启用 TLS 时按 cfg.TLS 建立 TLS/mTLS 连接，配置了 Token 时每次调用携带 Bearer token。
+// 多个代理（Endpoints 或 DNS 解析出的多个地址）之间按 Balancer 负载均衡，健康检查失败的代理被剔除；
+// 全部代理不可用时熔断器打开，调用直接返回 AgentCircuitOpen。调用 civ.analyzer.v1 的方法时在 metadata 中
+// 携带合约版本，代理不支持时返回 AgentVersionMismatch。多个代理启用 TLS 时，
+// 证书需包含相同的主机名，默认取第一个地址，可通过 tls.server_name 指定。
+func NewClient(cfg autoload.AgentConfig) (*Client, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS.Enabled {
+		tlsConf, err := tlsconfig.Client(cfg.TLS, serverName(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("agent %w", err)
+		}
+		creds = credentials.NewTLS(tlsConf)
+	} else if cfg.Token != "" {
+		slog.Warn("agent token is sent over a plaintext connection; enable agent.tls")
+	}
+	token := &tokenCredentials{requireTLS: cfg.TLS.Enabled}
+	breaker := newBreaker(cfg.CircuitBreaker)
+
+	target, opts := dialTarget(cfg)
+	opts = append(opts,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithPerRPCCredentials(token),
+		grpc.WithDefaultServiceConfig(serviceConfig(cfg)),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(versionUnaryInterceptor(), breaker.unaryInterceptor(), metrics.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(versionStreamInterceptor(), breaker.streamInterceptor(), metrics.StreamClientInterceptor()),
+	)
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		conn:     conn,
+		greeter:  hello.NewGreeterClient(conn),
+		analyzer: analyzer.NewAnalyzerClient(conn),
+		token:    token,
+		breaker:  breaker,
+	}
+	client.SetTimeout(cfg.Timeout)
+	client.SetToken(cfg.Token)
+	return client, nil
+}
+
+// SetTimeout 调整每次调用的超时时间，非正值使用默认值，可在运行时调用
+func (c *Client) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	c.timeout.Store(int64(timeout))
+}
+
+// SetCircuitBreaker 调整熔断阈值和熔断时长，可在运行时调用
+func (c *Client) SetCircuitBreaker(cfg autoload.CircuitBreakerConfig) {
+	c.breaker.configure(cfg)
+}
+
+// SetToken 替换调用代理时携带的 Bearer token，为空时不携带，可在运行时调用
+func (c *Client) SetToken(token string) {
+	c.token.token.Store(&token)
+}
+
+// SayHello 调用代理的 Greeter.SayHello
+func (c *Client) SayHello(ctx context.Context, name string, age int32) (*hello.HelloReply, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.timeout.Load()))
+	defer cancel()
+	reply, err := c.greeter.SayHello(ctx, &hello.HelloRequest{Name: name, Age: age})
+	return reply, grpcerr.FromError(err)
+}
+
+// Analyze 调用代理的 Analyzer.Analyze 分析任务日志，失败时返回按 gRPC 状态翻译的 BusinessError
+func (c *Client) Analyze(ctx context.Context, req *analyzer.AnalyzeRequest) (*analyzer.AnalyzeReply, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(c.timeout.Load()))
+	defer cancel()
+	reply, err := c.analyzer.Analyze(ctx, req)
+	return reply, grpcerr.FromError(err)
+}
+
+// Conn 返回底层连接，供健康检查等其他 gRPC 服务复用
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
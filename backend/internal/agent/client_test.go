@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/middleware"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpctest"
+	"civ/internal/pkg/tracing"
+	hello "civ/proto"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type greeterStub struct {
+	hello.UnimplementedGreeterServer
+	traceparent chan string
+}
+
+func (s *greeterStub) SayHello(ctx context.Context, req *hello.HelloRequest) (*hello.HelloReply, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	tp := ""
+	if v := md.Get("traceparent"); len(v) > 0 {
+		tp = v[0]
+	}
+	s.traceparent <- tp
+	return &hello.HelloReply{Message: "hi " + req.Name, Age: req.Age}, nil
+}
+
+func startStub(t *testing.T, srv hello.GreeterServer, opts ...grpc.ServerOption) string {
+	t.Helper()
+	return grpctest.Serve(t, func(s *grpc.Server) {
+		hello.RegisterGreeterServer(s, srv)
+	}, opts...)
+}
+
+func TestClientPropagatesTraceContext(t *testing.T) {
+	_, err := tracing.Init(context.Background(), autoload.TraceConfig{})
+	require.NoError(t, err)
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	otel.SetTracerProvider(provider)
+
+	stub := &greeterStub{traceparent: make(chan string, 1)}
+	client, err := NewClient(autoload.AgentConfig{Address: startStub(t, stub)})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "parent")
+	defer span.End()
+
+	reply, err := client.SayHello(ctx, "civ", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "hi civ", reply.Message)
+
+	assert.Contains(t, <-stub.traceparent, span.SpanContext().TraceID().String())
+}
+
+func TestClientSendsBearerToken(t *testing.T) {
+	stub := &greeterStub{traceparent: make(chan string, 2)}
+	address := startStub(t, stub, grpc.UnaryInterceptor(middleware.UnaryServerToken(func() string { return "s3cret" })))
+	client, err := NewClient(autoload.AgentConfig{Address: address, Token: "wrong"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.SayHello(context.Background(), "civ", 1)
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.NotLogin, businessError.GetCode())
+
+	client.SetToken("s3cret")
+	_, err = client.SayHello(context.Background(), "civ", 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,25 @@
+package agent
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// tokenCredentials 为每次调用附加 authorization: Bearer <token>，token 可在运行时替换，为空时不发送
+type tokenCredentials struct {
+	token      atomic.Pointer[string]
+	requireTLS bool
+}
+
+func (t *tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token := t.token.Load()
+	if token == nil || *token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + *token}, nil
+}
+
+// RequireTransportSecurity 启用 TLS 时拒绝在明文连接上发送 token
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.requireTLS
+}
@@ -0,0 +1,228 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/errors"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRegistry 进程级的代理注册表，由 InitRegistry 初始化
+var DefaultRegistry *Registry
+
+// InitRegistry 创建注册表并保存为 DefaultRegistry
+func InitRegistry(cfg autoload.AgentConfig) *Registry {
+	DefaultRegistry = NewRegistry(cfg)
+	return DefaultRegistry
+}
+
+// ModelInfo 代理支持的模型
+type ModelInfo struct {
+	Name             string `json:"name"`
+	MaxContextTokens int32  `json:"max_context_tokens"`
+}
+
+// AgentInfo 代理注册时上报的能力
+type AgentInfo struct {
+	ID            string      `json:"id"`
+	Address       string      `json:"address"`
+	Version       string      `json:"version"`
+	Models        []ModelInfo `json:"models"`
+	AnalysisTypes []string    `json:"analysis_types"`
+	Tools         []string    `json:"tools"`
+	RegisteredAt  time.Time   `json:"registered_at"`
+	LastSeen      time.Time   `json:"last_seen"`
+}
+
+// SupportsModel 判断代理是否支持指定模型
+func (a *AgentInfo) SupportsModel(model string) bool {
+	return slices.ContainsFunc(a.Models, func(m ModelInfo) bool { return m.Name == model })
+}
+
+type registered struct {
+	info   AgentInfo
+	client *Client
+}
+
+// Registry 记录通过 Register 上报的在线代理，并为每个代理维护一个连接。
+// 超过三个心跳间隔未上报的代理被视为下线
+type Registry struct {
+	mu       sync.Mutex
+	agents   map[string]*registered
+	base     autoload.AgentConfig
+	interval time.Duration
+	next     atomic.Uint64
+	now      func() time.Time
+}
+
+const defaultHeartbeatInterval = 10 * time.Second
+
+// NewRegistry 创建注册表，连接代理时沿用 cfg 中的 TLS、token、超时和熔断配置
+func NewRegistry(cfg autoload.AgentConfig) *Registry {
+	interval := cfg.HeartbeatInterval
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	return &Registry{
+		agents:   make(map[string]*registered),
+		base:     cfg,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// HeartbeatInterval 代理重复调用 Register 的间隔
+func (r *Registry) HeartbeatInterval() time.Duration {
+	return r.interval
+}
+
+func (r *Registry) ttl() time.Duration {
+	return 3 * r.interval
+}
+
+// Register 新增或刷新代理，地址变化时重建连接
+func (r *Registry) Register(info AgentInfo) error {
+	if info.ID == "" || info.Address == "" {
+		return errors.NewBusinessError(errors.InvalidParameter)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	existing, ok := r.agents[info.ID]
+	if ok && existing.info.Address == info.Address {
+		info.RegisteredAt = existing.info.RegisteredAt
+		info.LastSeen = now
+		existing.info = info
+		return nil
+	}
+
+	cfg := r.base
+	cfg.Address, cfg.Endpoints = info.Address, nil
+	client, err := NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("connect agent %s at %s: %w", info.ID, info.Address, err)
+	}
+	if ok {
+		_ = existing.client.Close()
+	}
+	info.RegisteredAt, info.LastSeen = now, now
+	r.agents[info.ID] = &registered{info: info, client: client}
+	slog.Info("agent registered", "id", info.ID, "address", info.Address, "version", info.Version, "models", len(info.Models))
+	return nil
+}
+
+// Reconfigure 将可热加载的超时、token 和熔断配置应用到已注册代理的连接，
+// 之后注册的代理也使用新配置
+func (r *Registry) Reconfigure(cfg autoload.AgentConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.base.Timeout, r.base.Token, r.base.CircuitBreaker = cfg.Timeout, cfg.Token, cfg.CircuitBreaker
+	for _, a := range r.agents {
+		a.client.SetTimeout(cfg.Timeout)
+		a.client.SetToken(cfg.Token)
+		a.client.SetCircuitBreaker(cfg.CircuitBreaker)
+	}
+}
+
+// Deregister 移除代理并关闭连接
+func (r *Registry) Deregister(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(id, "deregistered")
+}
+
+func (r *Registry) remove(id, reason string) {
+	if a, ok := r.agents[id]; ok {
+		_ = a.client.Close()
+		delete(r.agents, id)
+		slog.Info("agent removed", "id", id, "reason", reason)
+	}
+}
+
+// List 返回在线代理，按 ID 排序
+func (r *Registry) List() []AgentInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire()
+	agents := make([]AgentInfo, 0, len(r.agents))
+	for _, a := range r.agents {
+		agents = append(agents, a.info)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].ID < agents[j].ID })
+	return agents
+}
+
+// Pick 在支持 model 的在线代理之间轮询选择一个。没有任何在线代理时返回 nil，
+// 由调用方回退到静态配置的代理；有在线代理但都不支持该模型时返回 AgentModelNotFound
+func (r *Registry) Pick(model string) (*Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire()
+	if len(r.agents) == 0 {
+		return nil, nil
+	}
+	candidates := make([]*registered, 0, len(r.agents))
+	for _, a := range r.agents {
+		if a.info.SupportsModel(model) {
+			candidates = append(candidates, a)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.NewBusinessError(errors.AgentModelNotFound).WithParam("model", model)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].info.ID < candidates[j].info.ID })
+	return candidates[int(r.next.Add(1)-1)%len(candidates)].client, nil
+}
+
+// expire 移除超时未上报的代理，调用方需持有锁
+func (r *Registry) expire() {
+	deadline := r.now().Add(-r.ttl())
+	for id, a := range r.agents {
+		if a.info.LastSeen.Before(deadline) {
+			r.remove(id, "heartbeat timeout")
+		}
+	}
+}
+
+// Run 定期清理下线的代理，直到 ctx 结束后关闭全部连接
+func (r *Registry) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.mu.Lock()
+			for id := range r.agents {
+				r.remove(id, "shutdown")
+			}
+			r.mu.Unlock()
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			r.expire()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// Route 返回处理 model 的代理客户端：优先选择注册表中支持该模型的在线代理，
+// 没有在线代理时使用静态配置的 DefaultClient
+func Route(model string) (*Client, error) {
+	if DefaultRegistry != nil {
+		client, err := DefaultRegistry.Pick(model)
+		if err != nil || client != nil {
+			return client, err
+		}
+	}
+	if DefaultClient == nil {
+		return nil, errors.Wrap(errors.ServerError, fmt.Errorf("agent client is not initialized"))
+	}
+	return DefaultClient, nil
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T) (*Registry, *time.Time) {
+	t.Helper()
+	r := NewRegistry(autoload.AgentConfig{HeartbeatInterval: time.Second, Timeout: time.Second})
+	now := time.Unix(1_700_000_000, 0)
+	r.now = func() time.Time { return now }
+	t.Cleanup(func() {
+		for _, a := range r.List() {
+			r.Deregister(a.ID)
+		}
+	})
+	return r, &now
+}
+
+func TestRegistryPickRoutesByModel(t *testing.T) {
+	r, _ := newTestRegistry(t)
+
+	client, err := r.Pick("deepseek")
+	require.NoError(t, err)
+	assert.Nil(t, client, "empty registry falls back to the static client")
+
+	require.NoError(t, r.Register(AgentInfo{ID: "a", Address: "127.0.0.1:1", Models: []ModelInfo{{Name: "deepseek", MaxContextTokens: 64000}}}))
+	require.NoError(t, r.Register(AgentInfo{ID: "b", Address: "127.0.0.1:2", Models: []ModelInfo{{Name: "deepseek"}, {Name: "qwen"}}}))
+
+	client, err = r.Pick("qwen")
+	require.NoError(t, err)
+	assert.Same(t, r.agents["b"].client, client)
+
+	first, _ := r.Pick("deepseek")
+	second, _ := r.Pick("deepseek")
+	assert.NotSame(t, first, second, "agents supporting the model are used in turn")
+
+	_, err = r.Pick("gpt")
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.AgentModelNotFound, businessError.GetCode())
+}
+
+func TestRegistryHeartbeat(t *testing.T) {
+	r, now := newTestRegistry(t)
+
+	require.NoError(t, r.Register(AgentInfo{ID: "a", Address: "127.0.0.1:1", Version: "1.0"}))
+	registeredAt := r.List()[0].RegisteredAt
+
+	*now = now.Add(2 * time.Second)
+	require.NoError(t, r.Register(AgentInfo{ID: "a", Address: "127.0.0.1:1", Version: "1.1"}))
+	agents := r.List()
+	require.Len(t, agents, 1)
+	assert.Equal(t, "1.1", agents[0].Version)
+	assert.Equal(t, registeredAt, agents[0].RegisteredAt)
+	assert.Equal(t, *now, agents[0].LastSeen)
+
+	*now = now.Add(4 * time.Second)
+	assert.Empty(t, r.List(), "agent expires after three missed heartbeats")
+}
+
+func TestRegistryRejectsIncompleteInfo(t *testing.T) {
+	r, _ := newTestRegistry(t)
+	err := r.Register(AgentInfo{ID: "a"})
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.InvalidParameter, businessError.GetCode())
+}
@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpcerr"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// maxChunkBytes 单个 LogChunk 的上限，远小于 gRPC 默认的 4 MB 消息限制
+const maxChunkBytes = 1 << 20
+
+// errIdleTimeout 超过超时时间没有收到代理的消息
+var errIdleTimeout = stderrors.New("agent stream idle timeout")
+
+// LogSource 流式分析的日志来源，*io.SectionReader 满足该接口
+type LogSource interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// StreamProgress 流式分析的进度
+type StreamProgress struct {
+	LogSize   int64 `json:"log_size"`
+	BytesSent int64 `json:"bytes_sent"`
+	// Requests 代理发出的 LogRequest 次数
+	Requests int `json:"requests"`
+	// Usage 代理最后一次上报的累计用量，未上报时为 nil
+	Usage *analyzer.Usage `json:"usage,omitempty"`
+}
+
+// AnalyzeStream 调用代理的 Analyzer.AnalyzeStream：先发送 start，之后按代理的 LogRequest 从 log
+// 读取对应范围分片发送，直到代理返回结果。每发送完一次请求的数据或收到代理上报的用量时调用 progress（可为 nil）。
+//
+// 超时按空闲时间计算：超过客户端超时时间既没有收到代理的消息、也没有发出日志分片时返回 AgentTimeout。
+// ctx 取消时流被取消，返回的错误满足 errors.Is(err, context.Canceled)
+func (c *Client) AnalyzeStream(ctx context.Context, start *analyzer.AnalyzeStart, log LogSource, progress func(StreamProgress)) (*analyzer.AnalyzeReply, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	timeout := time.Duration(c.timeout.Load())
+	idle := time.AfterFunc(timeout, func() { cancel(errIdleTimeout) })
+	defer idle.Stop()
+
+	stream, err := c.analyzer.AnalyzeStream(ctx)
+	if err != nil {
+		return nil, streamError(ctx, err)
+	}
+	start.LogSize = log.Size()
+	if err := stream.Send(&analyzer.AnalyzeStreamRequest{Payload: &analyzer.AnalyzeStreamRequest_Start{Start: start}}); err != nil {
+		return nil, streamError(ctx, sendError(stream, err))
+	}
+
+	p := StreamProgress{LogSize: log.Size()}
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return nil, errors.Wrap(errors.AgentError, fmt.Errorf("agent closed the stream without a result"))
+		}
+		if err != nil {
+			return nil, streamError(ctx, err)
+		}
+		idle.Reset(timeout)
+
+		switch payload := msg.GetPayload().(type) {
+		case *analyzer.AnalyzeStreamReply_LogRequest:
+			// 慢速链路上发送大范围日志可能超过超时时间，每发出一个分片都视为活动
+			n, err := sendRange(stream, log, payload.LogRequest, func() { idle.Reset(timeout) })
+			if err != nil {
+				return nil, streamError(ctx, err)
+			}
+			p.BytesSent += n
+			p.Requests++
+			if progress != nil {
+				progress(p)
+			}
+		case *analyzer.AnalyzeStreamReply_Usage:
+			p.Usage = payload.Usage
+			if progress != nil {
+				progress(p)
+			}
+		case *analyzer.AnalyzeStreamReply_Result:
+			_ = stream.CloseSend()
+			return payload.Result, nil
+		}
+	}
+}
+
+// sendRange 按 maxChunkBytes 拆分并发送 req 请求的范围，超出日志末尾的部分被截断，返回发送的字节数。
+// 起点超出日志末尾的请求被拒绝。Send 在 HTTP/2 流控窗口用尽时阻塞，代理处理不过来时不会在内存中堆积分片。
+// 每个分片发送成功后调用 sent
+func sendRange(stream analyzer.Analyzer_AnalyzeStreamClient, log LogSource, req *analyzer.LogRequest, sent func()) (int64, error) {
+	offset := req.GetOffset()
+	if offset < 0 || offset > log.Size() || req.GetLength() <= 0 {
+		return 0, errors.NewBusinessError(errors.AgentInvalidRequest).
+			WithParam("reason", fmt.Sprintf("invalid log range offset=%d length=%d size=%d", offset, req.GetLength(), log.Size()))
+	}
+	// 先截断长度再相加，避免 offset+length 溢出
+	end := offset + min(req.GetLength(), log.Size()-offset)
+	buf := make([]byte, min(end-offset, maxChunkBytes))
+	var total int64
+	for {
+		n := min(end-offset, int64(len(buf)))
+		if n > 0 {
+			if _, err := log.ReadAt(buf[:n], offset); err != nil && err != io.EOF {
+				return total, fmt.Errorf("read log at %d: %w", offset, err)
+			}
+		}
+		chunk := &analyzer.LogChunk{Offset: offset, Data: buf[:n], Last: offset+n >= end}
+		if err := stream.Send(&analyzer.AnalyzeStreamRequest{Payload: &analyzer.AnalyzeStreamRequest_Chunk{Chunk: chunk}}); err != nil {
+			return total, sendError(stream, err)
+		}
+		sent()
+		offset += n
+		total += n
+		if chunk.Last {
+			return total, nil
+		}
+	}
+}
+
+// sendError Send 返回 io.EOF 时流已被代理结束，真正的状态需要从 Recv 获取
+func sendError(stream analyzer.Analyzer_AnalyzeStreamClient, err error) error {
+	if err != io.EOF {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func streamError(ctx context.Context, err error) error {
+	if context.Cause(ctx) == errIdleTimeout {
+		return errors.Wrap(errors.AgentTimeout, err)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("%w: %w", context.Cause(ctx), err)
+	}
+	return grpcerr.FromError(err)
+}
@@ -0,0 +1,145 @@
+package agent
+
+import (
+	"bytes"
+	"civ/config/autoload"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpctest"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// rangeAnalyzer 依次请求 ranges 中的日志范围，把收到的内容拼接后作为结果返回
+type rangeAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+	ranges [][2]int64
+	chunks chan int
+	silent bool
+	// delay 每读取一个分片前等待的时间，模拟慢速链路
+	delay time.Duration
+}
+
+func (a *rangeAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if a.silent {
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
+	var received bytes.Buffer
+	for _, r := range a.ranges {
+		if err := stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_LogRequest{
+			LogRequest: &analyzer.LogRequest{Offset: r[0], Length: r[1]},
+		}}); err != nil {
+			return err
+		}
+		for {
+			time.Sleep(a.delay)
+			msg, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			a.chunks <- len(msg.GetChunk().GetData())
+			received.Write(msg.GetChunk().GetData())
+			if msg.GetChunk().GetLast() {
+				break
+			}
+		}
+	}
+	return stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_Result{Result: &analyzer.AnalyzeReply{
+		Summary:  received.String(),
+		Category: first.GetStart().GetModel(),
+	}}})
+}
+
+func TestAnalyzeStreamServesRequestedRanges(t *testing.T) {
+	log := bytes.Repeat([]byte("0123456789"), maxChunkBytes/4)
+	size := int64(len(log))
+	agent := &rangeAnalyzer{
+		ranges: [][2]int64{{size - 100, 1000}, {0, 10}, {maxChunkBytes / 2, maxChunkBytes * 2}, {size - 5, math.MaxInt64}},
+		chunks: make(chan int, 16),
+	}
+	client, err := NewClient(autoload.AgentConfig{Address: startAnalyzer(t, agent)})
+	require.NoError(t, err)
+	defer client.Close()
+
+	var progress []StreamProgress
+	reply, err := client.AnalyzeStream(context.Background(), &analyzer.AnalyzeStart{Model: "deepseek"},
+		io.NewSectionReader(bytes.NewReader(log), 0, size), func(p StreamProgress) { progress = append(progress, p) })
+	require.NoError(t, err)
+
+	want := string(log[size-100:]) + string(log[:10]) + string(log[maxChunkBytes/2:]) + string(log[size-5:])
+	assert.Equal(t, want, reply.GetSummary())
+	assert.Equal(t, "deepseek", reply.GetCategory())
+	require.Len(t, progress, 4)
+	assert.Equal(t, StreamProgress{LogSize: size, BytesSent: int64(len(want)), Requests: 4}, progress[3])
+	close(agent.chunks)
+	for n := range agent.chunks {
+		assert.LessOrEqual(t, n, maxChunkBytes)
+	}
+}
+
+func TestAnalyzeStreamRejectsOffsetPastEnd(t *testing.T) {
+	agent := &rangeAnalyzer{ranges: [][2]int64{{11, 1}}, chunks: make(chan int, 1)}
+	client, err := NewClient(autoload.AgentConfig{Address: startAnalyzer(t, agent)})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.AnalyzeStream(context.Background(), &analyzer.AnalyzeStart{},
+		io.NewSectionReader(bytes.NewReader([]byte("0123456789")), 0, 10), nil)
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.AgentInvalidRequest, businessError.GetCode())
+}
+
+func TestAnalyzeStreamKeepsSlowUploadsAlive(t *testing.T) {
+	log := bytes.Repeat([]byte{'x'}, 3*maxChunkBytes)
+	agent := &rangeAnalyzer{
+		ranges: [][2]int64{{0, int64(len(log))}},
+		chunks: make(chan int, 8),
+		delay:  80 * time.Millisecond,
+	}
+	// 固定的小流控窗口使每次 Send 等到代理读取后才返回，整个范围的发送时间远超超时时间
+	address := grpctest.Serve(t, func(s *grpc.Server) {
+		analyzer.RegisterAnalyzerServer(s, agent)
+	}, grpc.InitialWindowSize(64<<10), grpc.InitialConnWindowSize(64<<10))
+	client, err := NewClient(autoload.AgentConfig{Address: address, Timeout: 150 * time.Millisecond})
+	require.NoError(t, err)
+	defer client.Close()
+
+	started := time.Now()
+	reply, err := client.AnalyzeStream(context.Background(), &analyzer.AnalyzeStart{},
+		io.NewSectionReader(bytes.NewReader(log), 0, int64(len(log))), nil)
+	require.NoError(t, err)
+	assert.Len(t, reply.GetSummary(), len(log))
+	assert.Greater(t, time.Since(started), 150*time.Millisecond, "upload outlasted the idle timeout")
+}
+
+func TestAnalyzeStreamCancellationAndIdleTimeout(t *testing.T) {
+	address := startAnalyzer(t, &rangeAnalyzer{silent: true})
+	log := io.NewSectionReader(bytes.NewReader(nil), 0, 0)
+
+	client, err := NewClient(autoload.AgentConfig{Address: address, Timeout: time.Minute})
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err = client.AnalyzeStream(ctx, &analyzer.AnalyzeStart{}, log, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	client.SetTimeout(50 * time.Millisecond)
+	_, err = client.AnalyzeStream(context.Background(), &analyzer.AnalyzeStart{}, log, nil)
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.AgentTimeout, businessError.GetCode())
+}
@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"civ/internal/pkg/errors"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// versionedPrefix 需要协商合约版本的方法前缀，Greeter 和健康检查不参与
+var versionedPrefix = "/" + string(analyzer.File_analyzer_v1_analyzer_proto.Package()) + "."
+
+// versionUnaryInterceptor 在 metadata 中携带 analyzer.APIVersion，代理不支持时返回 AgentVersionMismatch
+func versionUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !strings.HasPrefix(method, versionedPrefix) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, analyzer.APIVersionKey, analyzer.APIVersion)
+		var header, trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
+		return checkVersion(err, header, trailer)
+	}
+}
+
+// versionStreamInterceptor 与 versionUnaryInterceptor 相同，版本不兼容在首次 RecvMsg 时返回
+func versionStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if !strings.HasPrefix(method, versionedPrefix) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, analyzer.APIVersionKey, analyzer.APIVersion)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, checkVersion(err, nil, nil)
+		}
+		return &versionedStream{ClientStream: stream}, nil
+	}
+}
+
+type versionedStream struct {
+	grpc.ClientStream
+}
+
+func (s *versionedStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil || err == io.EOF {
+		return err
+	}
+	// 流已结束，Header 不会阻塞
+	header, _ := s.Header()
+	return checkVersion(err, header, s.Trailer())
+}
+
+// checkVersion 代理在 header 或 trailer 中返回了与后端不同的版本时，将失败转换为 AgentVersionMismatch。
+// 没有返回版本的失败无法判断是否由版本导致（例如代理没有实现该服务时返回 Unimplemented），原样返回，
+// 由 grpcerr.FromError 按状态码翻译
+func checkVersion(err error, header, trailer metadata.MD) error {
+	if err == nil {
+		return nil
+	}
+	agentVersion := firstValue(trailer, analyzer.APIVersionKey)
+	if agentVersion == "" {
+		agentVersion = firstValue(header, analyzer.APIVersionKey)
+	}
+	if agentVersion == "" || agentVersion == analyzer.APIVersion {
+		return err
+	}
+	return errors.Wrap(errors.AgentVersionMismatch, err).
+		WithParam("agent_version", agentVersion).
+		WithParam("api_version", analyzer.APIVersion)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"civ/config/autoload"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpctest"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// versionedAnalyzer 模拟只支持 version 的代理
+type versionedAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+	version string
+}
+
+func (a *versionedAnalyzer) Analyze(ctx context.Context, _ *analyzer.AnalyzeRequest) (*analyzer.AnalyzeReply, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if got := md.Get(analyzer.APIVersionKey); len(got) == 0 || got[0] != a.version {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(analyzer.APIVersionKey, a.version))
+		return nil, status.Error(codes.FailedPrecondition, "unsupported api version")
+	}
+	return &analyzer.AnalyzeReply{Summary: "ok"}, nil
+}
+
+func startAnalyzer(t *testing.T, srv analyzer.AnalyzerServer) string {
+	t.Helper()
+	return grpctest.Serve(t, func(s *grpc.Server) {
+		if srv != nil {
+			analyzer.RegisterAnalyzerServer(s, srv)
+		}
+	})
+}
+
+func TestClientNegotiatesAPIVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		server       analyzer.AnalyzerServer
+		code         int
+		agentVersion string
+	}{
+		{name: "compatible", server: &versionedAnalyzer{version: analyzer.APIVersion}},
+		{name: "newer agent", server: &versionedAnalyzer{version: "v2"}, code: errors.AgentVersionMismatch, agentVersion: "v2"},
+		// 没有返回版本的 Unimplemented 可能只是代理没有实现该服务，不能断定为版本不兼容
+		{name: "unimplemented without version", server: nil, code: errors.AgentError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(autoload.AgentConfig{Address: startAnalyzer(t, tt.server)})
+			require.NoError(t, err)
+			defer client.Close()
+
+			reply, err := client.Analyze(context.Background(), &analyzer.AnalyzeRequest{})
+			if tt.code == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", reply.GetSummary())
+				return
+			}
+			var businessError *errors.BusinessError
+			require.ErrorAs(t, err, &businessError)
+			assert.Equal(t, tt.code, businessError.GetCode())
+			if tt.agentVersion != "" {
+				assert.Equal(t, "analysis agent speaks API version "+tt.agentVersion+", backend speaks v1", businessError.MessageIn("en"))
+			}
+		})
+	}
+}
+
+// headerVersionAnalyzer 在 header 中返回版本后失败，模拟接受调用后在分析中出错的代理
+type headerVersionAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+	version string
+}
+
+func (a headerVersionAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	if err := stream.SendHeader(metadata.Pairs(analyzer.APIVersionKey, a.version)); err != nil {
+		return err
+	}
+	return status.Error(codes.FailedPrecondition, "log is empty")
+}
+
+func TestStreamChecksVersionFromHeader(t *testing.T) {
+	tests := []struct {
+		version string
+		code    int
+	}{
+		{version: analyzer.APIVersion, code: errors.AgentInvalidRequest},
+		{version: "v2", code: errors.AgentVersionMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			client, err := NewClient(autoload.AgentConfig{Address: startAnalyzer(t, headerVersionAnalyzer{version: tt.version})})
+			require.NoError(t, err)
+			defer client.Close()
+
+			log := io.NewSectionReader(strings.NewReader(""), 0, 0)
+			_, err = client.AnalyzeStream(context.Background(), &analyzer.AnalyzeStart{}, log, nil)
+			var businessError *errors.BusinessError
+			require.ErrorAs(t, err, &businessError)
+			assert.Equal(t, tt.code, businessError.GetCode())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package agents
+
+import (
+	"civ/internal/agent"
+	"civ/internal/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AgentsController struct {
+	controller.Api
+}
+
+func NewAgentsController() *AgentsController {
+	return &AgentsController{}
+}
+
+// List 返回通过 Register 注册且心跳未超时的代理；未启用 gRPC 服务时为空列表
+func (api AgentsController) List(c *gin.Context) {
+	agents := []agent.AgentInfo{}
+	if agent.DefaultRegistry != nil {
+		agents = agent.DefaultRegistry.List()
+	}
+	api.Success(c, agents)
+}
@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"civ/internal/controller"
+	"civ/internal/middleware"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalysisController struct {
+	controller.Api
+}
+
+func NewAnalysisController() *AnalysisController {
+	return &AnalysisController{}
+}
+
+// IDPath 分析记录 ID
+type IDPath struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+// JobPath 任务 ID
+type JobPath struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+// StartBody 发起分析的参数，请求体可省略
+type StartBody struct {
+	Model string `json:"model" binding:"max=64" doc:"分析使用的模型，为空时使用 agent.model"`
+}
+
+func (api AnalysisController) Start(c *gin.Context) {
+	var path JobPath
+	var body StartBody
+	if !api.BindURI(c, &path) {
+		return
+	}
+	if c.Request.ContentLength != 0 && !api.BindJSON(c, &body) {
+		return
+	}
+	analysis, err := service.NewAnalysisService().Start(c.Request.Context(), path.ID, body.Model, middleware.UserID(c))
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, analysis)
+}
+
+func (api AnalysisController) Get(c *gin.Context) {
+	var path IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	analysis, err := service.NewAnalysisService().Get(c.Request.Context(), path.ID)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, analysis)
+}
+
+func (api AnalysisController) Progress(c *gin.Context) {
+	var path IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	progress, err := service.NewAnalysisService().Progress(c.Request.Context(), path.ID)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, progress)
+}
+
+func (api AnalysisController) Cancel(c *gin.Context) {
+	var path IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	if err := service.NewAnalysisService().Cancel(c.Request.Context(), path.ID); err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c)
+}
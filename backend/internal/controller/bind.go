@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/i18n"
+	"civ/internal/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BindJSON 绑定并校验 JSON 请求体，失败时以 InvalidParameter 响应字段错误并返回 false
+func (api *Api) BindJSON(c *gin.Context, obj any) bool {
+	return api.bind(c, c.ShouldBindJSON(obj))
+}
+
+// BindQuery 绑定并校验查询参数，失败时以 InvalidParameter 响应字段错误并返回 false
+func (api *Api) BindQuery(c *gin.Context, obj any) bool {
+	return api.bind(c, c.ShouldBindQuery(obj))
+}
+
+// BindURI 绑定并校验路径参数，失败时以 InvalidParameter 响应字段错误并返回 false
+func (api *Api) BindURI(c *gin.Context, obj any) bool {
+	return api.bind(c, c.ShouldBindUri(obj))
+}
+
+func (api *Api) bind(c *gin.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+	fields := validation.Translate(err, i18n.FromGin(c))
+	api.FailCode(c, errors.InvalidParameter, gin.H{"errors": fields})
+	return false
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"bytes"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/validation"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createJobRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Retry int    `json:"retry" binding:"min=0,max=3"`
+}
+
+type bindResult struct {
+	Code int `json:"code"`
+	Data struct {
+		Errors []validation.FieldError `json:"errors"`
+	} `json:"data"`
+}
+
+func TestBindJSONReportsFieldErrors(t *testing.T) {
+	require.NoError(t, validation.Init())
+	gin.SetMode(gin.TestMode)
+	api := &Api{}
+	r := gin.New()
+	r.POST("/jobs", func(c *gin.Context) {
+		var req createJobRequest
+		if !api.BindJSON(c, &req) {
+			return
+		}
+		api.Success(c)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs", bytes.NewBufferString(`{"retry": 5}`)))
+
+	var result bindResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, errors.InvalidParameter, result.Code)
+	require.Len(t, result.Data.Errors, 2)
+	assert.Equal(t, validation.FieldError{Field: "name", Rule: "required", Message: "name为必填字段"}, result.Data.Errors[0])
+	assert.Equal(t, "retry", result.Data.Errors[1].Field)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/jobs", bytes.NewBufferString(`{"name": "build"}`)))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, errors.SUCCESS, result.Code)
+}
+
+func TestTranslateEnglish(t *testing.T) {
+	require.NoError(t, validation.Init())
+	err := binding.Validator.ValidateStruct(&createJobRequest{Retry: -1})
+	require.Error(t, err)
+
+	fields := validation.Translate(err, "en")
+	require.Len(t, fields, 2)
+	assert.Equal(t, validation.FieldError{Field: "name", Rule: "required", Message: "name is a required field"}, fields[0])
+	assert.Equal(t, "min", fields[1].Rule)
+}
@@ -0,0 +1,29 @@
+package errorcode
+
+import (
+	"civ/internal/controller"
+	"civ/internal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorCodeController struct {
+	controller.Api
+}
+
+func NewErrorCodeController() *ErrorCodeController {
+	return &ErrorCodeController{}
+}
+
+// CodesResponse 错误码清单，供客户端开发者对照
+type CodesResponse struct {
+	Ranges []errors.Range    `json:"ranges"`
+	Codes  []errors.CodeInfo `json:"codes"`
+}
+
+func (api ErrorCodeController) List(c *gin.Context) {
+	api.Success(c, CodesResponse{
+		Ranges: errors.Ranges(),
+		Codes:  errors.List(),
+	})
+}
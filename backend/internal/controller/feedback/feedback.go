@@ -0,0 +1,141 @@
+package feedback
+
+import (
+	"civ/internal/controller"
+	"civ/internal/controller/analysis"
+	"civ/internal/middleware"
+	"civ/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedbackController struct {
+	controller.Api
+}
+
+func NewFeedbackController() *FeedbackController {
+	return &FeedbackController{}
+}
+
+// RateBody 对分析的评价，评价人取自请求的 API 令牌
+type RateBody struct {
+	Rating    string `json:"rating" binding:"required,oneof=correct partially wrong"`
+	RootCause string `json:"root_cause" doc:"实际的根因"`
+	FixCommit string `json:"fix_commit" binding:"omitempty,hexadecimal,min=7,max=64" doc:"修复问题的提交"`
+	Comment   string `json:"comment"`
+}
+
+// FilterQuery 准确率统计和样本导出的过滤条件，见 service.FeedbackFilter
+type FilterQuery struct {
+	Project       string `form:"project"`
+	Model         string `form:"model"`
+	PromptName    string `form:"prompt_name"`
+	PromptVersion int    `form:"prompt_version" binding:"min=0"`
+	Category      string `form:"category"`
+	Rating        string `form:"rating" binding:"omitempty,oneof=correct partially wrong"`
+}
+
+func (q FilterQuery) filter() service.FeedbackFilter {
+	return service.FeedbackFilter{
+		Project:       q.Project,
+		Model:         q.Model,
+		PromptName:    q.PromptName,
+		PromptVersion: q.PromptVersion,
+		Category:      q.Category,
+		Rating:        q.Rating,
+	}
+}
+
+// AccuracyQuery 准确率统计条件
+type AccuracyQuery struct {
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=model prompt category" doc:"model、prompt 或 category，默认 model"`
+	FilterQuery
+}
+
+func (api FeedbackController) Rate(c *gin.Context) {
+	var path analysis.IDPath
+	var body RateBody
+	if !api.BindURI(c, &path) || !api.BindJSON(c, &body) {
+		return
+	}
+	feedback, err := service.NewFeedbackService().Rate(c.Request.Context(), path.ID, service.FeedbackInput{
+		Rating:    body.Rating,
+		RootCause: body.RootCause,
+		FixCommit: body.FixCommit,
+		Comment:   body.Comment,
+		RatedBy:   middleware.UserID(c),
+	})
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, feedback)
+}
+
+func (api FeedbackController) Get(c *gin.Context) {
+	var path analysis.IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	feedback, err := service.NewFeedbackService().Get(c.Request.Context(), path.ID)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, feedback)
+}
+
+func (api FeedbackController) Accuracy(c *gin.Context) {
+	var q AccuracyQuery
+	if !api.BindQuery(c, &q) {
+		return
+	}
+	groups, err := service.NewFeedbackService().Accuracy(c.Request.Context(), q.GroupBy, q.filter())
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, groups)
+}
+
+// Export 以 JSON Lines 返回标注样本，不使用统一的响应结构
+func (api FeedbackController) Export(c *gin.Context) {
+	var q FilterQuery
+	if !api.BindQuery(c, &q) {
+		return
+	}
+	w := &attachmentWriter{c: c}
+	err := service.NewFeedbackService().Export(c.Request.Context(), q.filter(), w)
+	switch {
+	case err != nil && !w.started:
+		api.Err(c, err)
+	case err != nil:
+		// 响应已开始写出，无法再返回错误结构，只记录到请求日志
+		_ = c.Error(err)
+	case !w.started:
+		// 没有样本时返回空文件
+		w.start()
+		c.Status(http.StatusOK)
+	}
+}
+
+// attachmentWriter 在写出第一行时才设置 JSON Lines 附件头，
+// 导出在写出前失败时错误响应不会被当作 feedback.jsonl 下载
+type attachmentWriter struct {
+	c       *gin.Context
+	started bool
+}
+
+func (w *attachmentWriter) start() {
+	w.c.Header("Content-Type", "application/x-ndjson")
+	w.c.Header("Content-Disposition", `attachment; filename="feedback.jsonl"`)
+	w.started = true
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+	return w.c.Writer.Write(p)
+}
@@ -0,0 +1,34 @@
+package health
+
+import (
+	"civ/internal/controller"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/health"
+	r "civ/internal/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	controller.Api
+}
+
+func NewHealthController() *HealthController {
+	return &HealthController{}
+}
+
+// Healthz 存活探针，进程能处理请求即返回成功
+func (api HealthController) Healthz(c *gin.Context) {
+	api.Success(c, gin.H{"status": health.StatusUp})
+}
+
+// Readyz 就绪探针，任一依赖检查失败或进程正在关闭时返回 503，data 中包含每项检查的详情
+func (api HealthController) Readyz(c *gin.Context) {
+	report := health.Default.Ready(c.Request.Context())
+	if !report.Ready {
+		r.Resp().SetHttpCode(http.StatusServiceUnavailable).WithData(report).FailCode(c, errors.ServerError)
+		return
+	}
+	api.Success(c, report)
+}
@@ -0,0 +1,43 @@
+package job
+
+import (
+	"civ/internal/controller"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobController struct {
+	controller.Api
+}
+
+func NewJobController() *JobController {
+	return &JobController{}
+}
+
+// ListQuery 仅用于生成接口文档，实际解析见 query.Parse 和 service.JobListRules
+type ListQuery struct {
+	Page           int    `form:"page" doc:"页码，从 1 开始"`
+	Size           int    `form:"size" doc:"每页条数，默认 20，最大 100"`
+	Cursor         string `form:"cursor" doc:"上一页返回的 next_cursor，优先于 page"`
+	Sort           string `form:"sort" doc:"排序字段：id、name、started_at、finished_at，- 前缀降序，默认 -id"`
+	Project        string `form:"project"`
+	Branch         string `form:"branch"`
+	Status         string `form:"status" doc:"逗号分隔多个值，如 failed,canceled"`
+	CommitSHA      string `form:"commit_sha"`
+	FinishedAtFrom string `form:"finished_at_from" doc:"2006-01-02 或 RFC 3339 时间"`
+	FinishedAtTo   string `form:"finished_at_to" doc:"仅日期时包含当天"`
+}
+
+func (api JobController) List(c *gin.Context) {
+	spec, ok := api.BindList(c, service.JobListRules)
+	if !ok {
+		return
+	}
+	jobs, total, err := service.NewJobService().List(c.Request.Context(), spec)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.SuccessPage(c, jobs, spec, total)
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/query"
+	r "civ/internal/pkg/response"
+	"civ/internal/pkg/validation"
+	stderrors "errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BindList 按资源白名单解析分页、排序和过滤参数，失败时以 InvalidParameter 响应并返回 false
+func (api *Api) BindList(c *gin.Context, rules query.Rules) (*query.Spec, bool) {
+	spec, err := query.Parse(c.Request.URL.Query(), rules)
+	if err != nil {
+		var queryErr *query.Error
+		if !stderrors.As(err, &queryErr) {
+			api.FailCode(c, errors.InvalidParameter)
+			return nil, false
+		}
+		api.FailCode(c, errors.InvalidParameter, gin.H{"errors": []validation.FieldError{{
+			Field:   queryErr.Param,
+			Rule:    "query",
+			Message: queryErr.Reason,
+		}}})
+		return nil, false
+	}
+	return spec, true
+}
+
+// SuccessPage 列表业务成功响应，分页链接基于当前请求地址生成
+func (api *Api) SuccessPage(c *gin.Context, items any, spec *query.Spec, total int64) {
+	r.Resp().PageSuccess(c, items, spec.Pagination(c.Request.URL, total))
+}
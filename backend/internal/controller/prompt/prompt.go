@@ -0,0 +1,173 @@
+package prompt
+
+import (
+	"civ/internal/controller"
+	"civ/internal/model"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromptController struct {
+	controller.Api
+}
+
+func NewPromptController() *PromptController {
+	return &PromptController{}
+}
+
+// ListQuery 仅用于生成接口文档，实际解析见 query.Parse 和 service.PromptListRules
+type ListQuery struct {
+	Page    int    `form:"page" doc:"页码，从 1 开始"`
+	Size    int    `form:"size" doc:"每页条数，默认 20，最大 100"`
+	Sort    string `form:"sort" doc:"排序字段：id、name、updated_at，- 前缀降序，默认 name"`
+	Name    string `form:"name"`
+	Project string `form:"project" doc:"空字符串为全局模板"`
+}
+
+// IDPath 模板 ID
+type IDPath struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+// VersionBody 模板版本内容，Body 为 text/template 语法，只能引用 Variables 中声明的变量
+type VersionBody struct {
+	Body      string                 `json:"body" binding:"required"`
+	Variables []model.PromptVariable `json:"variables" binding:"dive"`
+	Comment   string                 `json:"comment" binding:"max=255"`
+}
+
+func (b VersionBody) input() service.PromptInput {
+	return service.PromptInput{Body: b.Body, Variables: b.Variables, Comment: b.Comment}
+}
+
+// CreateBody 新建模板，内容作为第 1 个版本
+type CreateBody struct {
+	Name        string `json:"name" binding:"required,max=64"`
+	Project     string `json:"project" binding:"max=128"`
+	Description string `json:"description" binding:"max=512"`
+	VersionBody
+}
+
+// UpdateBody 修改模板描述，内容通过新增版本修改
+type UpdateBody struct {
+	Description string `json:"description" binding:"max=512"`
+}
+
+// AddVersionBody 新增版本，Activate 为 true 时立即作为当前版本
+type AddVersionBody struct {
+	VersionBody
+	Activate bool `json:"activate"`
+}
+
+// ActivateBody 设为当前版本的版本号
+type ActivateBody struct {
+	Version int `json:"version" binding:"required,min=1"`
+}
+
+func (api PromptController) List(c *gin.Context) {
+	spec, ok := api.BindList(c, service.PromptListRules)
+	if !ok {
+		return
+	}
+	templates, total, err := service.NewPromptService().List(c.Request.Context(), spec)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.SuccessPage(c, templates, spec, total)
+}
+
+func (api PromptController) Get(c *gin.Context) {
+	var path IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	detail, err := service.NewPromptService().Get(c.Request.Context(), path.ID)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, detail)
+}
+
+func (api PromptController) Create(c *gin.Context) {
+	var body CreateBody
+	if !api.BindJSON(c, &body) {
+		return
+	}
+	tpl := &model.PromptTemplate{Name: body.Name, Project: body.Project, Description: body.Description}
+	detail, err := service.NewPromptService().Create(c.Request.Context(), tpl, body.input())
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, detail)
+}
+
+func (api PromptController) Update(c *gin.Context) {
+	var path IDPath
+	var body UpdateBody
+	if !api.BindURI(c, &path) || !api.BindJSON(c, &body) {
+		return
+	}
+	tpl, err := service.NewPromptService().Update(c.Request.Context(), path.ID, body.Description)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, tpl)
+}
+
+func (api PromptController) Delete(c *gin.Context) {
+	var path IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	if err := service.NewPromptService().Delete(c.Request.Context(), path.ID); err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c)
+}
+
+func (api PromptController) ListVersions(c *gin.Context) {
+	var path IDPath
+	if !api.BindURI(c, &path) {
+		return
+	}
+	versions, err := service.NewPromptService().ListVersions(c.Request.Context(), path.ID)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, versions)
+}
+
+func (api PromptController) AddVersion(c *gin.Context) {
+	var path IDPath
+	var body AddVersionBody
+	if !api.BindURI(c, &path) || !api.BindJSON(c, &body) {
+		return
+	}
+	version, err := service.NewPromptService().AddVersion(c.Request.Context(), path.ID, body.input(), body.Activate)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, version)
+}
+
+func (api PromptController) Activate(c *gin.Context) {
+	var path IDPath
+	var body ActivateBody
+	if !api.BindURI(c, &path) || !api.BindJSON(c, &body) {
+		return
+	}
+	detail, err := service.NewPromptService().Activate(c.Request.Context(), path.ID, body.Version)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, detail)
+}
@@ -2,8 +2,10 @@ package controller
 
 import (
 	"civ/internal/pkg/errors"
-	"github.com/gin-gonic/gin"
+	"civ/internal/pkg/i18n"
+	"civ/internal/pkg/logger"
 	r "civ/internal/pkg/response"
+	"github.com/gin-gonic/gin"
 	"net/http"
 )
 
@@ -14,7 +16,7 @@ type Api struct {
 // Success 业务成功响应
 func (api *Api) Success(c *gin.Context, data ...any) {
 	response := r.Resp()
-	if data == nil {
+	if data != nil {
 		response.WithDataSuccess(c, data[0])
 		return
 	}
@@ -24,7 +26,7 @@ func (api *Api) Success(c *gin.Context, data ...any) {
 // FailCode 业务失败响应
 func (api *Api) FailCode(c *gin.Context, code int, data ...any) {
 	response := r.Resp()
-	if data == nil {
+	if data != nil {
 		response.WithData(data[0]).FailCode(c, code)
 		return
 	}
@@ -34,19 +36,39 @@ func (api *Api) FailCode(c *gin.Context, code int, data ...any) {
 // Fail 业务失败响应
 func (api *Api) Fail(c *gin.Context, code int, message string, data ...any) {
 	response := r.Resp()
-	if data == nil {
+	if data != nil {
 		response.WithData(data[0]).FailCode(c, code, message)
 		return
 	}
 	response.FailCode(c, code, message)
 }
 
+// Err 错误响应。BusinessError 按错误码登记的 HTTP 状态码和请求语言的文案返回，
+// 其他错误一律按 ServerError 返回；内部原因和调用栈只写入日志，不返回给客户端
 func (api *Api) Err(c *gin.Context, e error) {
 	businessError, err := api.AsBusinessError(e)
 	if err != nil {
-		api.FailCode(c, http.StatusInternalServerError, err.Error())
-		return
+		businessError = errors.Wrap(errors.ServerError, err)
 	}
+	logError(c, businessError)
+	r.Resp().SetHttpCode(businessError.HTTPStatus()).
+		FailCode(c, businessError.GetCode(), businessError.MessageIn(i18n.FromGin(c)))
+}
 
-	api.Fail(c, businessError.GetCode(), businessError.GetMessage())
+// logError 记录带内部原因或服务端故障的错误，日志中带有请求 ID
+func logError(c *gin.Context, e *errors.BusinessError) {
+	status := e.HTTPStatus()
+	if status < http.StatusInternalServerError && len(e.GetContextErr()) == 0 {
+		return
+	}
+	attrs := []any{"code", e.GetCode(), "status", status, "error", e.Error()}
+	if stack := e.Stack(); stack != "" {
+		attrs = append(attrs, "stack", stack)
+	}
+	l := logger.FromContext(c.Request.Context())
+	if status >= http.StatusInternalServerError {
+		l.Error("request failed", attrs...)
+		return
+	}
+	l.Warn("request failed", attrs...)
 }
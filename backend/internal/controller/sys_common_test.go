@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"civ/internal/pkg/errors"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrMapsStatusAndHidesDetail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := &Api{}
+	r := gin.New()
+	r.GET("/business", func(c *gin.Context) {
+		err := errors.Wrap(errors.JobDoesNotExist, fmt.Errorf("select jobs: connection reset"))
+		api.Err(c, err.WithParam("id", 7))
+	})
+	r.GET("/internal", func(c *gin.Context) {
+		api.Err(c, fmt.Errorf("dial tcp 10.0.0.5:3306: connection refused"))
+	})
+
+	cases := []struct {
+		path       string
+		wantStatus int
+		wantCode   int
+	}{
+		{"/business", http.StatusNotFound, errors.JobDoesNotExist},
+		{"/internal", http.StatusInternalServerError, errors.ServerError},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		assert.Equal(t, tc.wantStatus, w.Code, tc.path)
+
+		var result struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, tc.wantCode, result.Code, tc.path)
+		assert.NotContains(t, w.Body.String(), "connection", "internal detail must not leak")
+	}
+}
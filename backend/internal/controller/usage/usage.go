@@ -0,0 +1,64 @@
+package usage
+
+import (
+	"civ/internal/controller"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UsageController struct {
+	controller.Api
+}
+
+func NewUsageController() *UsageController {
+	return &UsageController{}
+}
+
+// ReportQuery 用量汇总条件，见 service.UsageQuery
+type ReportQuery struct {
+	Period  string `form:"period" binding:"omitempty,oneof=daily monthly" doc:"daily 或 monthly，默认 daily"`
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=project user" doc:"project 或 user，默认 project"`
+	From    string `form:"from" binding:"omitempty,datetime=2006-01-02" doc:"起始日期（包含），按日默认最近 30 天，按月默认最近 12 个月"`
+	To      string `form:"to" binding:"omitempty,datetime=2006-01-02" doc:"结束日期（包含），默认今天"`
+	Project string `form:"project"`
+	UserID  *uint  `form:"user_id"`
+}
+
+// BudgetQuery 查询费用上限的项目
+type BudgetQuery struct {
+	Project string `form:"project" binding:"required"`
+}
+
+func (api UsageController) Report(c *gin.Context) {
+	var q ReportQuery
+	if !api.BindQuery(c, &q) {
+		return
+	}
+	totals, err := service.NewUsageService().Report(c.Request.Context(), service.UsageQuery{
+		Period:  q.Period,
+		GroupBy: q.GroupBy,
+		From:    q.From,
+		To:      q.To,
+		Project: q.Project,
+		UserID:  q.UserID,
+	})
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, totals)
+}
+
+func (api UsageController) Budget(c *gin.Context) {
+	var q BudgetQuery
+	if !api.BindQuery(c, &q) {
+		return
+	}
+	status, err := service.NewUsageService().Budget(c.Request.Context(), q.Project)
+	if err != nil {
+		api.Err(c, err)
+		return
+	}
+	api.Success(c, status)
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/logger"
+	"civ/internal/pkg/response"
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserIDKey 认证用户的 ID 在 gin.Context 中的键
+const UserIDKey = "user_id"
+
+// Authenticate 校验 Authorization: Bearer <API 令牌>，通过后把令牌所属用户的 ID 写入 gin.Context。
+// 未携带令牌的请求作为匿名请求继续处理，需要用户的接口通过 UserID 判断；令牌无效时以 NotLogin 响应。
+// authenticate 返回令牌所属用户的 ID，令牌无效时返回 BusinessError
+func Authenticate(authenticate func(ctx context.Context, token string) (uint, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			response.FailCode(c, errors.NotLogin)
+			return
+		}
+		userID, err := authenticate(c.Request.Context(), token)
+		if err != nil {
+			var businessError *errors.BusinessError
+			if stderrors.As(err, &businessError) {
+				response.FailCode(c, businessError.GetCode())
+				return
+			}
+			logger.FromContext(c.Request.Context()).Error("authenticate request failed", "error", err)
+			response.FailCode(c, errors.ServerError)
+			return
+		}
+		c.Set(UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// UserID 返回 Authenticate 认证的用户 ID，匿名请求返回 nil
+func UserID(c *gin.Context) *uint {
+	if id, ok := c.Get(UserIDKey); ok {
+		if userID, ok := id.(uint); ok {
+			return &userID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpcerr"
+	"civ/internal/pkg/i18n"
+	"civ/internal/pkg/logger"
+	"context"
+	"crypto/subtle"
+	stderrors "errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// grpcRequestIDKey gRPC metadata 中的请求 ID，与 HTTP 的 X-Request-ID 对应
+const grpcRequestIDKey = "x-request-id"
+
+// UnaryServerRecovery 将 gRPC handler 中的 panic 转换为 ServerError 状态，
+// 业务码和请求 ID 放在 ErrorInfo 中，日志带请求 ID 和调用栈
+func UnaryServerRecovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = recoverGRPC(ctx, info.FullMethod, rec)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerRecovery 与 UnaryServerRecovery 相同，用于流式调用
+func StreamServerRecovery() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				err = recoverGRPC(ss.Context(), info.FullMethod, rec)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// UnaryServerToken 校验调用方的 authorization: Bearer <token>，token 返回空串时不校验，
+// 失败时返回 Unauthenticated，ErrorInfo 中带 NotLogin 业务码
+func UnaryServerToken(token func() string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkToken(ctx, token()); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerToken 与 UnaryServerToken 相同，用于流式调用
+func StreamServerToken(token func() string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkToken(ss.Context(), token()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkToken(ctx context.Context, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if got, ok := strings.CutPrefix(value, "Bearer "); ok &&
+			subtle.ConstantTimeCompare([]byte(got), []byte(expected)) == 1 {
+			return nil
+		}
+	}
+	requestID, language := incomingMeta(ctx)
+	return grpcerr.ToStatus(errors.NewBusinessError(errors.NotLogin), language, requestID).Err()
+}
+
+// GRPCError 供 gRPC handler 返回错误：按调用方的语言和请求 ID 编码为 status，
+// 非业务错误记录原因后按 ServerError 返回
+func GRPCError(ctx context.Context, err error) error {
+	requestID, language := incomingMeta(ctx)
+	var businessError *errors.BusinessError
+	if !stderrors.As(err, &businessError) {
+		logger.FromContext(ctx).Error("grpc handler failed", "request_id", requestID, "error", err)
+	}
+	return grpcerr.ToStatus(err, language, requestID).Err()
+}
+
+// incomingMeta 从 metadata 中取请求 ID 和协商后的语言
+func incomingMeta(ctx context.Context) (requestID, language string) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(grpcRequestIDKey); len(values) > 0 {
+			requestID = values[0]
+		}
+		if values := md.Get("accept-language"); len(values) > 0 {
+			language = i18n.Negotiate("", values[0])
+		}
+	}
+	return requestID, language
+}
+
+func recoverGRPC(ctx context.Context, method string, rec any) error {
+	requestID, language := incomingMeta(ctx)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	logger.FromContext(ctx).Error("panic recovered",
+		"request_id", requestID,
+		"method", method,
+		"panic", rec,
+		"stack", string(debug.Stack()),
+	)
+	cause := errors.Wrap(errors.ServerError, fmt.Errorf("panic: %v", rec))
+	return grpcerr.ToStatus(cause, language, requestID).Err()
+}
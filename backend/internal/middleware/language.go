@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"civ/internal/pkg/i18n"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language 按 ?lang= 和 Accept-Language 协商请求语言，写入 gin.Context 和 request context，
+// 并通过 Content-Language 告知客户端实际使用的语言
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		language := i18n.Negotiate(c.Query(i18n.QueryParam), c.GetHeader("Accept-Language"))
+		c.Set(i18n.ContextKey, language)
+		c.Request = c.Request.WithContext(i18n.WithLanguage(c.Request.Context(), language))
+		c.Header("Content-Language", strings.ReplaceAll(language, "_", "-"))
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/i18n"
+	"civ/internal/pkg/response"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguageLocalizesMessages(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Language())
+	r.GET("/jobs/1", func(c *gin.Context) {
+		err := errors.NewBusinessError(errors.JobDoesNotExist)
+		response.Fail(c, err.GetCode(), err.MessageIn(i18n.FromGin(c)))
+	})
+	r.GET("/fail", func(c *gin.Context) {
+		response.FailCode(c, errors.TooManyRequests)
+	})
+
+	cases := []struct {
+		path, accept, wantMsg, wantHeader string
+	}{
+		{"/jobs/1", "en-US,en;q=0.9", "job does not exist", "en"},
+		{"/jobs/1", "zh-CN", "任务不存在", "zh-CN"},
+		{"/fail?lang=en", "zh-CN", "Too many requests", "en"},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set("Accept-Language", tc.accept)
+		r.ServeHTTP(w, req)
+
+		var result response.Result
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		assert.Equal(t, tc.wantMsg, result.Msg, tc.path)
+		assert.Equal(t, tc.wantHeader, w.Header().Get("Content-Language"))
+	}
+}
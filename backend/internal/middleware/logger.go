@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"civ/internal/pkg/logger"
+	"civ/internal/pkg/tracing"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader 请求 ID 的 HTTP 头
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey 请求 ID 在 gin.Context 中的键
+	RequestIDKey = "requestId"
+	// RequestStartTimeKey 请求开始时间在 gin.Context 中的键，response 用它计算 cost
+	RequestStartTimeKey = "requestStartTime"
+)
+
+// RequestLogger 为每个请求分配请求 ID，将带有请求字段的 logger 写入 request context，
+// 并在请求结束后输出访问日志
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Set(RequestStartTimeKey, start)
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		c.Set(RequestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		fields := []any{
+			slog.String("request_id", requestID),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+		}
+		if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+			fields = append(fields, slog.String("trace_id", traceID))
+		}
+		ctx := logger.With(c.Request.Context(), fields...)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		l := logger.FromContext(ctx)
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("size", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		l.LogAttrs(ctx, level, "request", attrs...)
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/logger"
+	"civ/internal/pkg/response"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery 捕获 handler 中的 panic，记录带请求 ID 和调用栈的错误日志，并以 ServerError 响应，
+// data 中带请求 ID 方便反馈问题。客户端已断开时只记录日志。需放在 RequestLogger 之后
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+			logger.FromContext(c.Request.Context()).Error("panic recovered",
+				"panic", rec,
+				"stack", string(debug.Stack()),
+			)
+			if brokenPipe(rec) {
+				c.Abort()
+				return
+			}
+			response.Resp().
+				WithData(gin.H{"request_id": c.GetString(RequestIDKey)}).
+				FailCode(c, errors.ServerError)
+		}()
+		c.Next()
+	}
+}
+
+// brokenPipe 判断 panic 是否由客户端断开连接导致，此时无法再写入响应
+func brokenPipe(rec any) bool {
+	err, ok := rec.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !stderrors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if stderrors.As(opErr, &syscallErr) {
+		return stderrors.Is(syscallErr.Err, syscall.EPIPE) || stderrors.Is(syscallErr.Err, syscall.ECONNRESET)
+	}
+	return false
+}
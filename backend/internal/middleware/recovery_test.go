@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpcerr"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryRespondsWithServerError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestLogger(), Recovery())
+	r.GET("/panic", func(c *gin.Context) {
+		panic("nil map write")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var result struct {
+		Code int               `json:"code"`
+		Data map[string]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, errors.ServerError, result.Code)
+	assert.Equal(t, "req-42", result.Data["request_id"])
+	assert.NotContains(t, w.Body.String(), "nil map write")
+}
+
+func TestUnaryServerRecovery(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "req-7"))
+	_, err := UnaryServerRecovery()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/civ.Tools/GetJob"},
+		func(ctx context.Context, req any) (any, error) {
+			panic("boom")
+		})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	require.Len(t, st.Details(), 1)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, "10101", info.GetMetadata()[grpcerr.MetadataCode])
+	assert.Equal(t, "req-7", info.GetMetadata()[grpcerr.MetadataRequestID])
+}
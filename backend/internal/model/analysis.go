@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+const (
+	AnalysisPending   = "pending"
+	AnalysisRunning   = "running"
+	AnalysisSucceeded = "succeeded"
+	AnalysisFailed    = "failed"
+	AnalysisCanceled  = "canceled"
+)
+
+// Analysis 智能代理对一次 CI 任务的分析结果，同一任务可以被多次分析
+type Analysis struct {
+	ID     uint   `gorm:"primaryKey" json:"id"`
+	JobID  uint   `gorm:"index;not null" json:"job_id"`
+	Status string `gorm:"size:16;index;not null" json:"status"`
+	Model  string `gorm:"size:64" json:"model"`
+	// PromptName、PromptVersion 分析使用的提示词模板和版本，未使用模板时为空
+	PromptName    string     `gorm:"size:64" json:"prompt_name,omitempty"`
+	PromptVersion int        `json:"prompt_version,omitempty"`
+	Summary       string     `gorm:"type:text" json:"summary"`
+	RootCause     string     `gorm:"type:text" json:"root_cause"`
+	Suggestion    string     `gorm:"type:text" json:"suggestion"`
+	Category      string     `gorm:"size:64;index" json:"category"`
+	Error         string     `gorm:"type:text" json:"error,omitempty"`
+	RequestedBy   *uint      `json:"requested_by"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (Analysis) TableName() string {
+	return "analyses"
+}
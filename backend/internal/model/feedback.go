@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// 人工对分析结论的评价
+const (
+	FeedbackCorrect   = "correct"
+	FeedbackPartially = "partially"
+	FeedbackWrong     = "wrong"
+)
+
+// AnalysisFeedback 人工对一次分析的评价，每条分析保留最新的一条。RootCause、FixCommit 为实际的根因和修复提交，
+// 与分析结论一起作为评估提示词的标注样本
+type AnalysisFeedback struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID uint      `gorm:"uniqueIndex;not null" json:"analysis_id"`
+	Rating     string    `gorm:"size:16;index;not null" json:"rating"`
+	RootCause  string    `gorm:"type:text" json:"root_cause"`
+	FixCommit  string    `gorm:"size:64" json:"fix_commit"`
+	Comment    string    `gorm:"type:text" json:"comment"`
+	RatedBy    *uint     `json:"rated_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (AnalysisFeedback) TableName() string {
+	return "analysis_feedbacks"
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	JobSuccess  = "success"
+	JobFailed   = "failed"
+	JobCanceled = "canceled"
+	JobRunning  = "running"
+)
+
+// Job 一次 CI 任务运行，(Project, ExternalID) 唯一标识 CI 系统中的一次运行
+type Job struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	Project    string     `gorm:"size:128;not null;uniqueIndex:idx_jobs_project_external" json:"project"`
+	ExternalID string     `gorm:"size:128;not null;uniqueIndex:idx_jobs_project_external" json:"external_id"`
+	Name       string     `gorm:"size:255;not null" json:"name"`
+	Branch     string     `gorm:"size:255;index" json:"branch"`
+	CommitSHA  string     `gorm:"size:64" json:"commit_sha"`
+	Status     string     `gorm:"size:32;index" json:"status"`
+	LogPath    string     `gorm:"size:512" json:"log_path"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `gorm:"index" json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
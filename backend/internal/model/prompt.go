@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+// 提示词变量类型
+const (
+	PromptVarString = "string"
+	PromptVarInt    = "int"
+	PromptVarBool   = "bool"
+)
+
+// PromptTemplate 提示词模板。Project 为空的是全局模板，同名且指定 Project 的模板覆盖该项目使用的全局模板
+type PromptTemplate struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"size:64;not null;uniqueIndex:idx_prompt_templates_name_project" json:"name"`
+	Project     string `gorm:"size:128;not null;default:'';uniqueIndex:idx_prompt_templates_name_project" json:"project"`
+	Description string `gorm:"size:512" json:"description"`
+	// ActiveVersion 分析时使用的版本号，0 表示尚无可用版本
+	ActiveVersion int       `gorm:"not null;default:0" json:"active_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (PromptTemplate) TableName() string {
+	return "prompt_templates"
+}
+
+// PromptVariable 模板中可引用的变量，Default 在渲染时变量缺失且非必填时使用
+type PromptVariable struct {
+	Name        string `json:"name" binding:"required,max=64"`
+	Type        string `json:"type" binding:"required,oneof=string int bool"`
+	Required    bool   `json:"required"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PromptVersion 模板的一个不可变版本，Body 为 text/template 语法，如 {{.project}}
+type PromptVersion struct {
+	ID         uint             `gorm:"primaryKey" json:"id"`
+	TemplateID uint             `gorm:"not null;uniqueIndex:idx_prompt_versions_template_version" json:"template_id"`
+	Version    int              `gorm:"not null;uniqueIndex:idx_prompt_versions_template_version" json:"version"`
+	Body       string           `gorm:"type:text;not null" json:"body"`
+	Variables  []PromptVariable `gorm:"type:text;serializer:json" json:"variables"`
+	Comment    string           `gorm:"size:255" json:"comment"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+func (PromptVersion) TableName() string {
+	return "prompt_versions"
+}
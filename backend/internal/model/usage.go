@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// AnalysisUsage 一次分析的模型用量和费用。Project、RequestedBy 冗余自任务和分析，Day、Month 为记录时的
+// 本地日期（2006-01-02、2006-01），便于按项目、用户和周期汇总
+type AnalysisUsage struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	AnalysisID       uint      `gorm:"uniqueIndex;not null" json:"analysis_id"`
+	Project          string    `gorm:"size:128;index;not null" json:"project"`
+	RequestedBy      *uint     `gorm:"index" json:"requested_by"`
+	Model            string    `gorm:"size:64;not null" json:"model"`
+	PromptTokens     int64     `gorm:"not null" json:"prompt_tokens"`
+	CompletionTokens int64     `gorm:"not null" json:"completion_tokens"`
+	LatencyMs        int64     `gorm:"not null" json:"latency_ms"`
+	Cost             float64   `gorm:"not null" json:"cost"`
+	Day              string    `gorm:"size:10;index;not null" json:"day"`
+	Month            string    `gorm:"size:7;index;not null" json:"month"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (AnalysisUsage) TableName() string {
+	return "analysis_usages"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+type User struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Username     string    `gorm:"size:64;uniqueIndex;not null" json:"username"`
+	Email        string    `gorm:"size:255" json:"email"`
+	PasswordHash string    `gorm:"size:255;not null" json:"-"`
+	Role         string    `gorm:"size:16;not null;default:member" json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
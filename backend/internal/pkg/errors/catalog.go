@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed locales/*.yaml
+var builtinLocales embed.FS
+
+// Catalog 按语言保存错误码文案，文件名（不含扩展名）即语言，如 zh_CN.yaml、en.json
+type Catalog struct {
+	mu    sync.RWMutex
+	texts map[string]map[int]string
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{texts: make(map[string]map[int]string)}
+}
+
+// defaultCatalog 内置文案，Init 会在其上叠加 system.messages_dir 中的文件
+var defaultCatalog = mustBuiltinCatalog()
+
+func mustBuiltinCatalog() *Catalog {
+	c := NewCatalog()
+	if err := c.LoadFS(builtinLocales, "locales"); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// LoadDir 加载目录中的 .yaml/.yml/.json 文案文件，已有的 code 被覆盖
+func (c *Catalog) LoadDir(dir string) error {
+	return c.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS 加载 fsys 中 dir 目录下的文案文件
+func (c *Catalog) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		ext := path.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		texts, err := parseMessages(content, ext)
+		if err != nil {
+			return fmt.Errorf("error messages %s: %w", entry.Name(), err)
+		}
+		c.Add(strings.TrimSuffix(entry.Name(), ext), texts)
+	}
+	return nil
+}
+
+func parseMessages(content []byte, ext string) (map[int]string, error) {
+	raw := make(map[string]string)
+	var err error
+	if ext == ".json" {
+		err = json.Unmarshal(content, &raw)
+	} else {
+		err = yaml.Unmarshal(content, &raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	texts := make(map[int]string, len(raw))
+	for key, text := range raw {
+		code, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not an error code", key)
+		}
+		texts[code] = text
+	}
+	return texts, nil
+}
+
+// Add 合并某种语言的文案
+func (c *Catalog) Add(language string, texts map[int]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing, ok := c.texts[language]
+	if !ok {
+		existing = make(map[int]string, len(texts))
+		c.texts[language] = existing
+	}
+	for code, text := range texts {
+		existing[code] = text
+	}
+}
+
+// Lookup 返回指定语言下 code 的文案模板
+func (c *Catalog) Lookup(language string, code int) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	text, ok := c.texts[language][code]
+	return text, ok
+}
+
+// Languages 返回已加载的语言，按名称排序
+func (c *Catalog) Languages() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	languages := make([]string, 0, len(c.texts))
+	for language := range c.texts {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+var placeholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// format 用 params 替换 {name} 占位符。没有对应参数的占位符连同多余的空格一起去掉：
+// "job {id} not found" -> "job not found"，"任务 {id} 不存在" -> "任务不存在"
+func format(text string, params map[string]any) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	var sb strings.Builder
+	last := 0
+	for _, m := range placeholder.FindAllStringSubmatchIndex(text, -1) {
+		before := text[last:m[0]]
+		if value, ok := params[text[m[2]:m[3]]]; ok {
+			sb.WriteString(before)
+			sb.WriteString(fmt.Sprint(value))
+			last = m[1]
+			continue
+		}
+		trimmed := strings.TrimRight(before, " ")
+		sb.WriteString(trimmed)
+		last = m[1]
+		rest := strings.TrimLeft(text[last:], " ")
+		spaced := len(trimmed) < len(before) || len(rest) < len(text[last:])
+		if spaced && trimmed != "" && rest != "" && isASCIIWord(trimmed[len(trimmed)-1]) && isASCIIWord(rest[0]) {
+			sb.WriteByte(' ')
+		}
+		last = len(text) - len(rest)
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+func isASCIIWord(b byte) bool {
+	return b < utf8.RuneSelf && b != ' '
+}
@@ -1,17 +1,105 @@
 package errors
 
+import (
+	"civ/internal/pkg/i18n"
+	"fmt"
+	"net/http"
+)
+
+const (
+	SUCCESS                   = 0
+	FAILURE                   = 1
+	AuthorizationError        = 403
+	NotFound                  = 404
+	NotLogin                  = 401
+	InvalidParameter          = 10000
+	UserDoesNotExist          = 10001
+	UserAlreadyExists         = 10002
+	ServerError               = 10101
+	TooManyRequests           = 10102
+	JobDoesNotExist           = 10201
+	AnalysisDoesNotExist      = 10202
+	AnalysisFailed            = 10203
+	AnalysisNotRunning        = 10204
+	AnalysisCanceled          = 10205
+	CommitDoesNotExist        = 10206
+	AnalysisNoResult          = 10207
+	AgentUnavailable          = 10301
+	AgentTimeout              = 10302
+	AgentOverloaded           = 10303
+	AgentInvalidRequest       = 10304
+	AgentError                = 10305
+	AgentModelNotFound        = 10306
+	AgentCircuitOpen          = 10307
+	AgentVersionMismatch      = 10308
+	PromptDoesNotExist        = 10401
+	PromptAlreadyExists       = 10402
+	PromptVersionDoesNotExist = 10403
+	PromptInvalid             = 10404
+	DailyBudgetExceeded       = 10501
+	MonthlyBudgetExceeded     = 10502
+)
+
+// 模块名，每个模块在自己的区间内定义错误码
 const (
-	SUCCESS            = 0
-	FAILURE            = 1
-	AuthorizationError = 403
-	NotFound           = 404
-	NotLogin           = 401
-	InvalidParameter   = 10000
-	UserDoesNotExist   = 10001
-	ServerError        = 10101
-	TooManyRequests    = 10102
+	ModuleCommon   = "common"
+	ModuleUser     = "user"
+	ModuleAnalysis = "analysis"
+	ModuleAgent    = "agent"
+	ModulePrompt   = "prompt"
+	ModuleUsage    = "usage"
 )
 
+func init() {
+	RegisterRange(ModuleCommon, 0, 999)
+	RegisterRange(ModuleCommon, 10000, 10000)
+	RegisterRange(ModuleUser, 10001, 10099)
+	RegisterRange(ModuleCommon, 10100, 10199)
+	RegisterRange(ModuleAnalysis, 10200, 10299)
+	RegisterRange(ModuleAgent, 10300, 10399)
+	RegisterRange(ModulePrompt, 10400, 10499)
+	RegisterRange(ModuleUsage, 10500, 10599)
+
+	Register(ModuleCommon, SUCCESS, FAILURE, NotLogin, AuthorizationError, NotFound, InvalidParameter, ServerError, TooManyRequests)
+	Register(ModuleUser, UserDoesNotExist, UserAlreadyExists)
+	Register(ModuleAnalysis, JobDoesNotExist, AnalysisDoesNotExist, AnalysisFailed, AnalysisNotRunning, AnalysisCanceled, CommitDoesNotExist, AnalysisNoResult)
+	Register(ModuleAgent, AgentUnavailable, AgentTimeout, AgentOverloaded, AgentInvalidRequest, AgentError, AgentModelNotFound, AgentCircuitOpen, AgentVersionMismatch)
+	Register(ModulePrompt, PromptDoesNotExist, PromptAlreadyExists, PromptVersionDoesNotExist, PromptInvalid)
+	Register(ModuleUsage, DailyBudgetExceeded, MonthlyBudgetExceeded)
+
+	RegisterHTTPStatus(http.StatusBadRequest, InvalidParameter, AgentInvalidRequest, AgentModelNotFound, PromptInvalid)
+	RegisterHTTPStatus(http.StatusUnauthorized, NotLogin)
+	RegisterHTTPStatus(http.StatusForbidden, AuthorizationError)
+	RegisterHTTPStatus(http.StatusNotFound, NotFound, UserDoesNotExist, JobDoesNotExist, AnalysisDoesNotExist, CommitDoesNotExist, PromptDoesNotExist, PromptVersionDoesNotExist)
+	RegisterHTTPStatus(http.StatusConflict, UserAlreadyExists, AnalysisNotRunning, AnalysisCanceled, AnalysisNoResult, PromptAlreadyExists)
+	RegisterHTTPStatus(http.StatusTooManyRequests, TooManyRequests, AgentOverloaded, DailyBudgetExceeded, MonthlyBudgetExceeded)
+	RegisterHTTPStatus(http.StatusInternalServerError, FAILURE, ServerError)
+	RegisterHTTPStatus(http.StatusBadGateway, AnalysisFailed, AgentError, AgentVersionMismatch)
+	RegisterHTTPStatus(http.StatusServiceUnavailable, AgentUnavailable, AgentCircuitOpen)
+	RegisterHTTPStatus(http.StatusGatewayTimeout, AgentTimeout)
+}
+
+// Init 叠加 messagesDir 中的文案文件（为空时只使用内置文案），并检查错误码区间冲突、
+// 重复注册以及内置语言缺失的文案，应在启动时调用
+func Init(messagesDir string) error {
+	if messagesDir != "" {
+		if err := defaultCatalog.LoadDir(messagesDir); err != nil {
+			return fmt.Errorf("load error messages from %s: %w", messagesDir, err)
+		}
+	}
+	for _, language := range defaultCatalog.Languages() {
+		i18n.Register(language)
+	}
+	return codeRegistry.check(defaultCatalog, []string{i18n.ZhCN, i18n.En})
+}
+
+// Codes 返回所有已注册的错误码，按升序排列
+func Codes() []int {
+	codeRegistry.mu.Lock()
+	defer codeRegistry.mu.Unlock()
+	return codeRegistry.sortedCodes()
+}
+
 type ErrorText struct {
 	Language string
 }
@@ -20,18 +108,18 @@ func NewErrorText(language string) *ErrorText {
 	return &ErrorText{Language: language}
 }
 
-func (e *ErrorText) Text(code int) (str string) {
-	var ok bool
-	switch e.Language {
-	case "zh_CN":
-		str, ok = zhCNText[code]
-	case "en":
-		str, ok = enUSText[code]
-	default:
-		str, ok = zhCNText[code]
-	}
-	if !ok {
-		return "Unknown error"
+// Text 返回 code 的文案，占位符被去掉
+func (e *ErrorText) Text(code int) string {
+	return e.Format(code, nil)
+}
+
+// Format 按 i18n.Fallbacks 的顺序查找文案并用 params 替换 {name} 占位符，
+// 某种语言缺少该 code 时回退到下一种
+func (e *ErrorText) Format(code int, params map[string]any) string {
+	for _, language := range i18n.Fallbacks(e.Language) {
+		if text, ok := defaultCatalog.Lookup(language, code); ok {
+			return format(text, params)
+		}
 	}
-	return str
+	return "Unknown error"
 }
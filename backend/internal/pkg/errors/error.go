@@ -1,27 +1,94 @@
 package errors
 
 import (
-	"civ/config"
+	"civ/internal/pkg/i18n"
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
 )
 
+// BusinessError 业务错误。未指定 message 时，文案在输出时按请求语言解析。
+// contextErr 是内部原因，只写日志，不返回给客户端
 type BusinessError struct {
 	code       int
 	message    string
+	params     map[string]any
 	contextErr []error
+	stack      []uintptr
 }
 
 func (e *BusinessError) Error() string {
-	return fmt.Sprintf("[Code]:%d [Msg]:%s, [context error] %s", e.code, e.message, e.contextErr)
+	msg := fmt.Sprintf("[Code]:%d [Msg]:%s", e.code, e.GetMessage())
+	if len(e.contextErr) == 0 {
+		return msg
+	}
+	causes := make([]string, len(e.contextErr))
+	for i, err := range e.contextErr {
+		causes[i] = err.Error()
+	}
+	return msg + ", [context error] " + strings.Join(causes, "; ")
+}
+
+// Unwrap 返回内部原因，使 errors.Is/As 可以穿过 BusinessError
+func (e *BusinessError) Unwrap() []error {
+	return e.contextErr
+}
+
+// Is 错误码相同的 BusinessError 视为同一错误，如 errors.Is(err, NewBusinessError(JobDoesNotExist))
+func (e *BusinessError) Is(target error) bool {
+	t, ok := target.(*BusinessError)
+	return ok && t.code == e.code
+}
+
+// HTTPStatus 返回错误码对应的 HTTP 状态码
+func (e *BusinessError) HTTPStatus() int {
+	return HTTPStatus(e.code)
+}
+
+// Stack 返回创建时的调用栈，仅在 debug 日志级别下采集，否则为空
+func (e *BusinessError) Stack() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
 }
 
 func (e *BusinessError) GetCode() int {
 	return e.code
 }
 
+// GetMessage 返回默认语言的文案
 func (e *BusinessError) GetMessage() string {
-	return e.message
+	return e.MessageIn(i18n.Default())
+}
+
+// MessageIn 返回指定语言的文案，显式设置过 message 时原样返回
+func (e *BusinessError) MessageIn(language string) string {
+	if e.message != "" {
+		return e.message
+	}
+	return NewErrorText(language).Format(e.code, e.params)
+}
+
+// WithParam 设置文案中 {name} 占位符的值
+func (e *BusinessError) WithParam(name string, value any) *BusinessError {
+	if e.params == nil {
+		e.params = make(map[string]any)
+	}
+	e.params[name] = value
+	return e
 }
 
 func (e *BusinessError) SetCode(code int) {
@@ -40,20 +107,38 @@ func (e *BusinessError) GetContextErr() []error {
 	return e.contextErr
 }
 
+// NewBusinessError 创建业务错误，不传 message 时由 MessageIn 按请求语言取 code 对应的文案
 func NewBusinessError(code int, message ...string) *BusinessError {
-	var msg string
-	cfg := config.GetConfig()
+	err := new(BusinessError)
+	err.SetCode(code)
 	if message != nil {
-		msg = message[0]
-	} else {
-		msg = NewErrorText(cfg.System.Language).Text(code)
+		err.SetMessage(message[0])
 	}
+	err.captureStack()
+	return err
+}
+
+// Wrap 创建以 cause 为内部原因的业务错误
+func Wrap(code int, cause error) *BusinessError {
 	err := new(BusinessError)
 	err.SetCode(code)
-	err.SetMessage(msg)
+	err.SetContextErr(cause)
+	err.captureStack()
 	return err
 }
 
+const maxStackDepth = 32
+
+// captureStack 在 debug 日志级别下记录调用栈，跳过 runtime.Callers、captureStack 和构造函数本身
+func (e *BusinessError) captureStack() {
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	e.stack = pcs[:n]
+}
+
 type Error struct{}
 
 func (e *Error) AsBusinessError(err error) (*BusinessError, error) {
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"civ/internal/pkg/i18n"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinCatalogIsComplete(t *testing.T) {
+	require.NoError(t, Init(""))
+	for _, info := range List() {
+		assert.NotEmpty(t, info.Messages[i18n.ZhCN], info.Code)
+		assert.NotEmpty(t, info.Messages[i18n.En], info.Code)
+	}
+}
+
+func TestParameterizedMessages(t *testing.T) {
+	err := NewBusinessError(JobDoesNotExist).WithParam("id", 42)
+	assert.Equal(t, "job 42 does not exist", err.MessageIn(i18n.En))
+	assert.Equal(t, "任务 42 不存在", err.MessageIn(i18n.ZhCN))
+	assert.Equal(t, "job does not exist", NewErrorText(i18n.En).Text(JobDoesNotExist), "missing params are dropped")
+	assert.Equal(t, "任务不存在", NewErrorText(i18n.ZhCN).Text(JobDoesNotExist))
+	assert.Equal(t, "no job #7", format("no job #{id}", map[string]any{"id": 7}))
+}
+
+func TestRegistryDetectsCollisions(t *testing.T) {
+	r := &registry{codes: make(map[int]string)}
+	r.registerRange("a", 100, 199)
+	r.registerRange("b", 150, 250)
+	r.registerRange("c", 200, 299)
+	r.register("a", 100, 100)
+	r.register("c", 150)
+
+	catalog := NewCatalog()
+	catalog.Add(i18n.En, map[int]string{100: "a"})
+	err := r.check(catalog, []string{i18n.En, i18n.ZhCN})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "range 150-250 overlaps a 100-199")
+	assert.Contains(t, err.Error(), "code 100 already registered by a")
+	assert.Contains(t, err.Error(), "code 150 is outside its ranges")
+	assert.Contains(t, err.Error(), "code 100 (a) has no zh_CN message")
+}
+
+func TestCatalogLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"10201": "no job #{id}"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ja.yaml"), []byte("10201: ジョブ {id} が見つかりません\n"), 0o644))
+
+	catalog := mustBuiltinCatalog()
+	require.NoError(t, catalog.LoadDir(dir))
+	text, _ := catalog.Lookup(i18n.En, JobDoesNotExist)
+	assert.Equal(t, "no job #{id}", text)
+	text, _ = catalog.Lookup(i18n.En, AnalysisFailed)
+	assert.Equal(t, "analysis failed", text, "codes missing from the override keep the built-in text")
+	assert.Equal(t, []string{i18n.En, "ja", i18n.ZhCN}, catalog.Languages())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("oops: text\n"), 0o644))
+	assert.Error(t, catalog.LoadDir(dir))
+}
+
+func TestBusinessErrorWrapping(t *testing.T) {
+	cause := fmt.Errorf("dial agent: %w", io.ErrUnexpectedEOF)
+	err := fmt.Errorf("analyze: %w", Wrap(AnalysisFailed, cause))
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.ErrorIs(t, err, NewBusinessError(AnalysisFailed))
+	assert.NotErrorIs(t, err, NewBusinessError(ServerError))
+	assert.Contains(t, err.Error(), "[context error] dial agent: unexpected EOF")
+
+	var businessError *BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, http.StatusBadGateway, businessError.HTTPStatus())
+	assert.Equal(t, http.StatusNotFound, HTTPStatus(JobDoesNotExist))
+	assert.Equal(t, http.StatusTooManyRequests, HTTPStatus(TooManyRequests))
+}
+
+func TestStackCapturedInDebug(t *testing.T) {
+	assert.Empty(t, NewBusinessError(ServerError).Stack())
+
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	assert.Contains(t, NewBusinessError(ServerError).Stack(), "TestStackCapturedInDebug")
+}
@@ -0,0 +1,170 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Range 模块占用的错误码区间 [Min, Max]
+type Range struct {
+	Module string `json:"module"`
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+}
+
+type registry struct {
+	mu       sync.Mutex
+	ranges   []Range
+	codes    map[int]string
+	statuses map[int]int
+	problems []string
+}
+
+var codeRegistry = &registry{codes: make(map[int]string), statuses: make(map[int]int)}
+
+// RegisterRange 声明模块占用的错误码区间，一般在模块的 init 中调用。
+// 与已有区间重叠时不会 panic，而是在 Init 时统一报告
+func RegisterRange(module string, min, max int) {
+	codeRegistry.registerRange(module, min, max)
+}
+
+// Register 在模块自己的区间内声明错误码
+func Register(module string, codes ...int) {
+	codeRegistry.register(module, codes...)
+}
+
+// RegisterHTTPStatus 指定错误码响应时使用的 HTTP 状态码
+func RegisterHTTPStatus(status int, codes ...int) {
+	r := codeRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, code := range codes {
+		r.statuses[code] = status
+	}
+}
+
+// HTTPStatus 返回错误码的 HTTP 状态码，未指定时返回 200，由响应体中的 code 区分结果
+func HTTPStatus(code int) int {
+	r := codeRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if status, ok := r.statuses[code]; ok {
+		return status
+	}
+	return http.StatusOK
+}
+
+func (r *registry) registerRange(module string, min, max int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if min > max {
+		r.problems = append(r.problems, fmt.Sprintf("module %s: invalid range %d-%d", module, min, max))
+		return
+	}
+	for _, existing := range r.ranges {
+		if min <= existing.Max && existing.Min <= max {
+			r.problems = append(r.problems, fmt.Sprintf("module %s: range %d-%d overlaps %s %d-%d",
+				module, min, max, existing.Module, existing.Min, existing.Max))
+			return
+		}
+	}
+	r.ranges = append(r.ranges, Range{Module: module, Min: min, Max: max})
+}
+
+func (r *registry) register(module string, codes ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, code := range codes {
+		if owner, ok := r.codes[code]; ok {
+			r.problems = append(r.problems, fmt.Sprintf("module %s: code %d already registered by %s", module, code, owner))
+			continue
+		}
+		if !r.owns(module, code) {
+			r.problems = append(r.problems, fmt.Sprintf("module %s: code %d is outside its ranges", module, code))
+			continue
+		}
+		r.codes[code] = module
+	}
+}
+
+func (r *registry) owns(module string, code int) bool {
+	for _, rg := range r.ranges {
+		if rg.Module == module && code >= rg.Min && code <= rg.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// check 返回注册冲突以及内置语言中缺失文案的错误码
+func (r *registry) check(catalog *Catalog, languages []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	problems := append([]string(nil), r.problems...)
+	for _, code := range r.sortedCodes() {
+		for _, language := range languages {
+			if _, ok := catalog.Lookup(language, code); !ok {
+				problems = append(problems, fmt.Sprintf("code %d (%s) has no %s message", code, r.codes[code], language))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("error code registry: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (r *registry) sortedCodes() []int {
+	codes := make([]int, 0, len(r.codes))
+	for code := range r.codes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+// CodeInfo 错误码及其各语言文案，用于对外列出
+type CodeInfo struct {
+	Code     int               `json:"code"`
+	Module   string            `json:"module"`
+	Status   int               `json:"status" doc:"HTTP 状态码"`
+	Messages map[string]string `json:"messages" doc:"语言到文案模板，{name} 为参数占位符"`
+}
+
+// List 返回所有已注册的错误码，按 code 升序
+func List() []CodeInfo {
+	r := codeRegistry
+	r.mu.Lock()
+	codes := r.sortedCodes()
+	modules := make(map[int]string, len(codes))
+	for _, code := range codes {
+		modules[code] = r.codes[code]
+	}
+	r.mu.Unlock()
+
+	languages := defaultCatalog.Languages()
+	infos := make([]CodeInfo, 0, len(codes))
+	for _, code := range codes {
+		info := CodeInfo{Code: code, Module: modules[code], Status: HTTPStatus(code), Messages: make(map[string]string)}
+		for _, language := range languages {
+			if text, ok := defaultCatalog.Lookup(language, code); ok {
+				info.Messages[language] = text
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Ranges 返回已登记的错误码区间，按起始值升序
+func Ranges() []Range {
+	r := codeRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ranges := append([]Range(nil), r.ranges...)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Min < ranges[j].Min })
+	return ranges
+}
@@ -0,0 +1,33 @@
+// Package gormcallback 在 GORM 各类语句执行前后注册成对的回调，供指标、链路追踪等插件复用
+package gormcallback
+
+import "gorm.io/gorm"
+
+// Hook 按语句类型（create、query 等）返回该类语句使用的回调
+type Hook func(operation string) func(*gorm.DB)
+
+// Register 在 create、query、update、delete、row、raw 前后注册 before 和 after 回调，
+// 回调名为 prefix:before_<operation> 和 prefix:after_<operation>
+func Register(db *gorm.DB, prefix string, before, after Hook) error {
+	cb := db.Callback()
+	processors := []struct {
+		operation     string
+		before, after func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, p := range processors {
+		if err := p.before(prefix+":before_"+p.operation, before(p.operation)); err != nil {
+			return err
+		}
+		if err := p.after(prefix+":after_"+p.operation, after(p.operation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Package grpcerr 在 gRPC status 和 BusinessError 之间转换。
+//
+// 调用代理失败时，gRPC 状态码和 errdetails 被翻译为专门的业务码，文案按请求语言输出；
+// 本服务的 gRPC 接口则把 BusinessError 编码为 status，业务码放在 ErrorInfo 中。
+package grpcerr
+
+import (
+	"civ/internal/pkg/errors"
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Domain ErrorInfo 中标识本服务的域
+	Domain = "civ"
+	// MetadataCode ErrorInfo.Metadata 中业务码的键
+	MetadataCode = "code"
+	// MetadataRequestID ErrorInfo.Metadata 中请求 ID 的键
+	MetadataRequestID = "request_id"
+)
+
+var statusCodes = map[codes.Code]int{
+	codes.Unavailable:        errors.AgentUnavailable,
+	codes.DeadlineExceeded:   errors.AgentTimeout,
+	codes.ResourceExhausted:  errors.AgentOverloaded,
+	codes.InvalidArgument:    errors.AgentInvalidRequest,
+	codes.FailedPrecondition: errors.AgentInvalidRequest,
+	codes.OutOfRange:         errors.AgentInvalidRequest,
+}
+
+// grpcCodes 不按 HTTP 状态推导 gRPC 状态码的业务码
+var grpcCodes = map[int]codes.Code{
+	errors.AgentVersionMismatch: codes.FailedPrecondition,
+}
+
+var (
+	mu          sync.RWMutex
+	reasonCodes = map[string]int{
+		"MODEL_NOT_FOUND": errors.AgentModelNotFound,
+	}
+	// paramKeys 可作为文案参数返回给客户端的 ErrorInfo.Metadata 键
+	paramKeys = map[string]bool{
+		"model": true,
+	}
+)
+
+// RegisterReason 将 ErrorInfo.Reason 映射到业务码，优先于按 gRPC 状态码的映射
+func RegisterReason(reason string, code int) {
+	mu.Lock()
+	defer mu.Unlock()
+	reasonCodes[reason] = code
+}
+
+// RegisterParam 允许 ErrorInfo.Metadata 中的键作为文案参数，未登记的键不会出现在返回给客户端的文案中
+func RegisterParam(keys ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, k := range keys {
+		paramKeys[k] = true
+	}
+}
+
+func isParam(key string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return paramKeys[key]
+}
+
+func reasonCode(reason string) (int, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	code, ok := reasonCodes[reason]
+	return code, ok
+}
+
+// FromError 将 gRPC 调用返回的错误转换为 BusinessError，原始错误作为内部原因保留。
+// 非 gRPC status 的错误和 nil 原样返回。
+//
+// BadRequest、RetryInfo 和 ErrorInfo.Metadata 中经 RegisterParam 登记的键作为文案参数：
+// reason（校验失败的字段和描述）、retry_after 以及登记的 metadata 键。
+// status 的原始文案只保留在内部原因中，随错误日志输出，不返回给客户端。
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var businessError *errors.BusinessError
+	if stderrors.As(err, &businessError) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	code, ok := statusCodes[st.Code()]
+	if !ok {
+		code = errors.AgentError
+	}
+	params := map[string]any{}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if c, ok := reasonCode(d.GetReason()); ok {
+				code = c
+			} else if d.GetDomain() == Domain {
+				if c, err := strconv.Atoi(d.GetMetadata()[MetadataCode]); err == nil {
+					code = c
+				}
+			}
+			for k, v := range d.GetMetadata() {
+				if isParam(k) {
+					params[k] = v
+				}
+			}
+		case *errdetails.BadRequest:
+			violations := make([]string, 0, len(d.GetFieldViolations()))
+			for _, v := range d.GetFieldViolations() {
+				violations = append(violations, v.GetField()+": "+v.GetDescription())
+			}
+			if len(violations) > 0 {
+				params["reason"] = strings.Join(violations, "; ")
+			}
+		case *errdetails.RetryInfo:
+			params["retry_after"] = d.GetRetryDelay().AsDuration()
+		}
+	}
+
+	result := errors.Wrap(code, err)
+	for k, v := range params {
+		result.WithParam(k, v)
+	}
+	return result
+}
+
+// ToStatus 将错误编码为 gRPC status：BusinessError 的业务码和请求 ID 放在 ErrorInfo 中，
+// 文案按 language 输出；其他错误按 ServerError 处理，不暴露内部细节
+func ToStatus(err error, language, requestID string) *status.Status {
+	var businessError *errors.BusinessError
+	if !stderrors.As(err, &businessError) {
+		businessError = errors.Wrap(errors.ServerError, err)
+	}
+	code, ok := grpcCodes[businessError.GetCode()]
+	if !ok {
+		code = grpcCode(businessError.HTTPStatus())
+	}
+	st := status.New(code, businessError.MessageIn(language))
+	info := &errdetails.ErrorInfo{
+		Reason: "BUSINESS_ERROR",
+		Domain: Domain,
+		Metadata: map[string]string{
+			MetadataCode: strconv.Itoa(businessError.GetCode()),
+		},
+	}
+	if requestID != "" {
+		info.Metadata[MetadataRequestID] = requestID
+	}
+	if withDetails, err := st.WithDetails(info); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// grpcCode 按 HTTP 状态码选择最接近的 gRPC 状态码，未登记状态码的业务错误为 Unknown
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusInternalServerError, http.StatusBadGateway:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
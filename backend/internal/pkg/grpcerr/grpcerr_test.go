@@ -0,0 +1,87 @@
+package grpcerr
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func withDetails(t *testing.T, st *status.Status, details ...*errdetails.ErrorInfo) error {
+	t.Helper()
+	for _, d := range details {
+		var err error
+		st, err = st.WithDetails(d)
+		require.NoError(t, err)
+	}
+	return st.Err()
+}
+
+func TestFromErrorMapsStatusCodes(t *testing.T) {
+	cases := map[codes.Code]int{
+		codes.Unavailable:       errors.AgentUnavailable,
+		codes.DeadlineExceeded:  errors.AgentTimeout,
+		codes.ResourceExhausted: errors.AgentOverloaded,
+		codes.InvalidArgument:   errors.AgentInvalidRequest,
+		codes.Internal:          errors.AgentError,
+	}
+	for grpcCode, want := range cases {
+		err := FromError(status.Error(grpcCode, "boom"))
+		var businessError *errors.BusinessError
+		require.ErrorAs(t, err, &businessError, grpcCode.String())
+		assert.Equal(t, want, businessError.GetCode(), grpcCode.String())
+		assert.Equal(t, grpcCode, status.Code(businessError.Unwrap()[0]), "original status is kept as the cause")
+	}
+	assert.NoError(t, FromError(nil))
+}
+
+func TestFromErrorUsesDetails(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "bad request").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "log", Description: "exceeds 3MB"}},
+	}, &errdetails.RetryInfo{RetryDelay: durationpb.New(time.Second)})
+	require.NoError(t, err)
+	businessError := FromError(st.Err()).(*errors.BusinessError)
+	assert.Equal(t, "analysis agent rejected the request: log: exceeds 3MB", businessError.MessageIn(i18n.En))
+
+	err = withDetails(t, status.New(codes.NotFound, "no model"), &errdetails.ErrorInfo{
+		Reason:   "MODEL_NOT_FOUND",
+		Metadata: map[string]string{"model": "gpt-x"},
+	})
+	businessError = FromError(err).(*errors.BusinessError)
+	assert.Equal(t, errors.AgentModelNotFound, businessError.GetCode())
+	assert.Equal(t, "模型 gpt-x 不可用", businessError.MessageIn(i18n.ZhCN))
+}
+
+func TestFromErrorHidesAgentText(t *testing.T) {
+	err := withDetails(t, status.New(codes.InvalidArgument, "open /srv/agent/prompts/x.txt: permission denied"), &errdetails.ErrorInfo{
+		Reason:   "MODEL_NOT_FOUND",
+		Metadata: map[string]string{"model": "gpt-x", "api_key": "sk-secret"},
+	})
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, FromError(err), &businessError)
+	assert.Equal(t, "model gpt-x is not available", businessError.MessageIn(i18n.En))
+
+	businessError = FromError(status.Error(codes.InvalidArgument, "open /srv/agent/prompts/x.txt: permission denied")).(*errors.BusinessError)
+	assert.NotContains(t, businessError.MessageIn(i18n.En), "/srv/agent")
+	assert.Contains(t, businessError.Error(), "/srv/agent", "raw message is kept in the cause for logs")
+}
+
+func TestToStatusRoundTrip(t *testing.T) {
+	st := ToStatus(errors.NewBusinessError(errors.JobDoesNotExist).WithParam("id", 3), i18n.En, "req-1")
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "job 3 does not exist", st.Message())
+
+	businessError := FromError(st.Err()).(*errors.BusinessError)
+	assert.Equal(t, errors.JobDoesNotExist, businessError.GetCode())
+
+	st = ToStatus(assert.AnError, i18n.En, "")
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "Internal server error", st.Message())
+}
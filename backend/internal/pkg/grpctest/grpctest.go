@@ -0,0 +1,24 @@
+// Package grpctest 为测试在本地随机端口上启动 gRPC 服务
+package grpctest
+
+import (
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// Serve 在 127.0.0.1 的随机端口上启动 gRPC 服务，register 在启动前注册服务实现。
+// 测试结束时停止服务，返回监听地址。
+func Serve(t testing.TB, register func(*grpc.Server), opts ...grpc.ServerOption) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := grpc.NewServer(opts...)
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
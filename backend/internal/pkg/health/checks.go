@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// DBCheck 通过 Ping 检查数据库连接
+func DBCheck(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// GRPCCheck 通过标准 gRPC 健康检查协议检查服务，service 为空时检查整个服务端
+func GRPCCheck(conn grpc.ClientConnInterface, service string) Check {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("service %q is %s", service, resp.GetStatus())
+		}
+		return nil
+	}
+}
+
+// QueueCheck 当队列积压超过 max 时判定为未就绪
+func QueueCheck(depth func() int, max int) Check {
+	return func(ctx context.Context) error {
+		if n := depth(); n > max {
+			return fmt.Errorf("queue backlog %d exceeds %d", n, max)
+		}
+		return nil
+	}
+}
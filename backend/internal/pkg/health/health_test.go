@@ -0,0 +1,48 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadyReportsEveryCheck(t *testing.T) {
+	h := NewChecker(time.Second)
+	h.Register("mysql", func(ctx context.Context) error { return nil })
+	h.Register("agent", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	report := h.Ready(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusUp, report.Checks["mysql"].Status)
+	assert.Equal(t, StatusDown, report.Checks["agent"].Status)
+	assert.Equal(t, "connection refused", report.Checks["agent"].Error)
+}
+
+func TestReadyTimesOutSlowChecks(t *testing.T) {
+	h := NewChecker(10 * time.Millisecond)
+	h.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := h.Ready(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, StatusDown, report.Checks["slow"].Status)
+}
+
+func TestNotReadyWhileShuttingDown(t *testing.T) {
+	h := NewChecker(time.Second)
+	h.Register("queue", QueueCheck(func() int { return 1 }, 10))
+	assert.True(t, h.Ready(context.Background()).Ready)
+
+	h.SetShuttingDown()
+
+	report := h.Ready(context.Background())
+	assert.False(t, report.Ready)
+	assert.True(t, report.ShuttingDown)
+}
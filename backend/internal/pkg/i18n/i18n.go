@@ -0,0 +1,167 @@
+// Package i18n 负责请求语言的协商和传递，错误信息、校验信息按这里确定的语言输出。
+package i18n
+
+import (
+	"civ/config"
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ZhCN = "zh_CN"
+	En   = "en"
+
+	// QueryParam 显式指定语言的查询参数，优先于 Accept-Language
+	QueryParam = "lang"
+	// ContextKey 请求语言在 gin.Context 中的键
+	ContextKey = "language"
+)
+
+var (
+	mu sync.RWMutex
+	// supported 支持的语言，顺序即同等权重时的优先级
+	supported = []string{ZhCN, En}
+)
+
+// Register 追加支持的语言，如外部错误文案目录中新增的语言
+func Register(language string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !contains(supported, language) {
+		supported = append(supported, language)
+	}
+}
+
+// Supported 返回支持的语言，顺序即同等权重时的优先级
+func Supported() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Clone(supported)
+}
+
+type contextKey struct{}
+
+// WithLanguage 将语言写入 context，供拿不到 gin.Context 的下游使用
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, contextKey{}, language)
+}
+
+// FromContext 返回 context 中的语言，没有时返回配置的默认语言
+func FromContext(ctx context.Context) string {
+	if language, ok := ctx.Value(contextKey{}).(string); ok && language != "" {
+		return language
+	}
+	return Default()
+}
+
+// FromGin 返回协商后的请求语言，未经过 Language 中间件时返回配置的默认语言
+func FromGin(c *gin.Context) string {
+	if language := c.GetString(ContextKey); language != "" {
+		return language
+	}
+	return Default()
+}
+
+// Default 配置的默认语言
+func Default() string {
+	if language := config.GetConfig().System.Language; language != "" {
+		return language
+	}
+	return ZhCN
+}
+
+// Fallbacks 返回查找文案时依次尝试的语言：请求语言、配置的默认语言、zh_CN
+func Fallbacks(language string) []string {
+	chain := make([]string, 0, 3)
+	for _, l := range []string{Match(language), Default(), ZhCN} {
+		if l != "" && !contains(chain, l) {
+			chain = append(chain, l)
+		}
+	}
+	return chain
+}
+
+// Match 将 zh-CN、zh_Hans、en-US 这类语言标签匹配到支持的语言，先精确匹配再按主语言匹配，
+// 无法匹配时返回空串
+func Match(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "-", "_"))
+	if tag == "" {
+		return ""
+	}
+	languages := Supported()
+	for _, language := range languages {
+		if strings.ToLower(language) == tag {
+			return language
+		}
+	}
+	base, _, _ := strings.Cut(tag, "_")
+	for _, language := range languages {
+		supportedBase, _, _ := strings.Cut(strings.ToLower(language), "_")
+		if supportedBase == base {
+			return language
+		}
+	}
+	return ""
+}
+
+// Negotiate 按 ?lang= 和 Accept-Language（含 q 权重）选出语言，都不匹配时返回默认语言
+func Negotiate(query, acceptLanguage string) string {
+	if language := Match(query); language != "" {
+		return language
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if language := Match(tag); language != "" {
+			return language
+		}
+	}
+	return Default()
+}
+
+type weightedTag struct {
+	tag string
+	q   float64
+}
+
+// parseAcceptLanguage 解析 Accept-Language，按 q 值降序返回标签，q=0 的标签被丢弃
+func parseAcceptLanguage(header string) []string {
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weightedTag{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		query, accept, want string
+	}{
+		{"en", "zh-CN", En},
+		{"", "en-US,en;q=0.9", En},
+		{"", "fr-FR, en;q=0.5, zh;q=0.8", ZhCN},
+		{"", "zh-Hans-CN", ZhCN},
+		{"", "en;q=0, fr", Default()},
+		{"klingon", "", Default()},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, Negotiate(tc.query, tc.accept), "%q %q", tc.query, tc.accept)
+	}
+}
+
+func TestFallbacks(t *testing.T) {
+	assert.Equal(t, []string{En, ZhCN}, Fallbacks("en-GB"))
+	assert.Equal(t, []string{ZhCN}, Fallbacks("zh_CN"))
+	assert.Equal(t, []string{ZhCN}, Fallbacks("fr"))
+}
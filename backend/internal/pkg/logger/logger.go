@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"civ/config/autoload"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// level 为全局 logger 的级别，可在运行时通过 SetLevel 调整
+var level = new(slog.LevelVar)
+
+// New 根据日志配置创建 slog.Logger
+//
+// Output 为空或 "stdout" 时写标准输出，"stderr" 写标准错误，其余值视为文件路径，
+// 按 MaxSize/MaxBackups/MaxAge 进行滚动。
+func New(cfg autoload.LogConfig) (*slog.Logger, error) {
+	l, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	return newLogger(cfg, l)
+}
+
+func newLogger(cfg autoload.LogConfig, leveler slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{
+		Level:       leveler,
+		ReplaceAttr: redactAttr,
+	}
+
+	w := newWriter(cfg)
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		handler = slog.NewTextHandler(w, opts)
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+	return slog.New(handler), nil
+}
+
+// Init 创建 logger 并设置为全局默认，标准库 log 的输出也会转发到该 logger
+func Init(cfg autoload.LogConfig) error {
+	if err := SetLevel(cfg.Level); err != nil {
+		return err
+	}
+	l, err := newLogger(cfg, level)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(l)
+	return nil
+}
+
+// SetLevel 调整全局 logger 的级别，立即生效
+func SetLevel(s string) error {
+	l, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	level.Set(l)
+	return nil
+}
+
+// ParseLevel 解析日志级别，空值默认为 info
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q", s)
+}
+
+// Fatal 记录错误日志后退出进程
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// StdLogger 返回写入默认 slog.Logger 的标准库 *log.Logger，供只接受 *log.Logger 的第三方组件使用
+func StdLogger(level slog.Level) *log.Logger {
+	return slog.NewLogLogger(slog.Default().Handler(), level)
+}
+
+func newWriter(cfg autoload.LogConfig) io.Writer {
+	switch cfg.Output {
+	case "", "stdout":
+		return os.Stdout
+	case "stderr":
+		return os.Stderr
+	}
+	return &lumberjack.Logger{
+		Filename:   cfg.Output,
+		MaxSize:    cfg.MaxSize,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAge,
+		Compress:   cfg.Compress,
+		LocalTime:  true,
+	}
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue 替换敏感字段后的占位值
+const RedactedValue = "******"
+
+// secretWords 敏感字段名的结尾，与键名按 _ . - 切分后的单段或相邻两段拼接比较
+var secretWords = []string{"password", "passwd", "secret", "token", "apikey", "credential", "credentials", "privatekey"}
+
+// IsSecretKey 判断配置键名是否像敏感字段。按 _ . - 切分后逐段匹配，
+// tool_secret、accessToken、api_key 视为敏感，prompt_tokens 这类计数不视为敏感
+func IsSecretKey(key string) bool {
+	segments := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return r == '_' || r == '.' || r == '-'
+	})
+	for i, segment := range segments {
+		if isSecretSegment(segment) || (i > 0 && isSecretSegment(segments[i-1]+segment)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSecretSegment(segment string) bool {
+	for _, word := range secretWords {
+		if strings.HasSuffix(segment, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact 返回配置项的副本，其中敏感键对应的值被替换为 RedactedValue
+//
+// 用于在日志中输出 viper.AllSettings() 这类嵌套 map。
+func Redact(settings map[string]any) map[string]any {
+	out := make(map[string]any, len(settings))
+	for k, v := range settings {
+		if IsSecretKey(k) {
+			out[k] = RedactedValue
+			continue
+		}
+		if nested, ok := v.(map[string]any); ok {
+			out[k] = Redact(nested)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if IsSecretKey(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+	if a.Value.Kind() == slog.KindAny {
+		if m, ok := a.Value.Any().(map[string]any); ok {
+			return slog.Any(a.Key, Redact(m))
+		}
+	}
+	return a
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	settings := map[string]any{
+		"mysql": map[string]any{
+			"host":     "127.0.0.1",
+			"password": "root",
+		},
+		"llm_api_key": "sk-xxx",
+	}
+
+	redacted := Redact(settings)
+
+	assert.Equal(t, "127.0.0.1", redacted["mysql"].(map[string]any)["host"])
+	assert.Equal(t, RedactedValue, redacted["mysql"].(map[string]any)["password"])
+	assert.Equal(t, RedactedValue, redacted["llm_api_key"])
+	assert.Equal(t, "root", settings["mysql"].(map[string]any)["password"], "Redact should not modify the input")
+}
+
+func TestIsSecretKey(t *testing.T) {
+	for _, key := range []string{"password", "mysql.password", "grpc.tool_secret", "llm_api_key", "accessToken", "private-key", "x-civ-tool-token"} {
+		assert.True(t, IsSecretKey(key), key)
+	}
+	for _, key := range []string{"prompt_tokens", "completion_tokens", "total_tokens", "key_file", "max_context_tokens", "username"} {
+		assert.False(t, IsSecretKey(key), key)
+	}
+}
+
+func TestHandlerRedactsSecretAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: redactAttr}))
+
+	l.Info("connect", "user", "root", "password", "root-pass", "prompt_tokens", 12)
+
+	assert.Contains(t, buf.String(), `"user":"root"`)
+	assert.Contains(t, buf.String(), `"prompt_tokens":12`)
+	assert.NotContains(t, buf.String(), "root-pass")
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"civ/internal/pkg/gormcallback"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+var dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: namespace,
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "GORM query latency by operation, table and status.",
+	Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+}, []string{"operation", "table", "status"})
+
+// GormPlugin 通过 GORM 回调记录每条语句的耗时，并注册连接池统计指标
+type GormPlugin struct {
+	dbName string
+}
+
+func NewGormPlugin(dbName string) *GormPlugin {
+	return &GormPlugin{dbName: dbName}
+}
+
+func (p *GormPlugin) Name() string {
+	return "civ:metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	if err := gormcallback.Register(db, "metrics", before, after); err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	err = prometheus.Register(collectors.NewDBStatsCollector(sqlDB, p.dbName))
+	if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		return nil
+	}
+	return err
+}
+
+func before(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		db.InstanceSet(gormStartKey, time.Now())
+	}
+}
+
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+		status := "ok"
+		if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+			status = "error"
+		}
+		dbQueryDuration.WithLabelValues(operation, db.Statement.Table, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "civ"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	queueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_depth",
+		Help:      "Number of items waiting in each queue.",
+	}, []string{"queue"})
+
+	analyses = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyses_total",
+		Help:      "Total number of analyses performed by result.",
+	}, []string{"result"})
+
+	llmTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "llm",
+		Name:      "tokens_total",
+		Help:      "Total number of LLM tokens consumed by model and kind (prompt/completion).",
+	}, []string{"model", "kind"})
+)
+
+// Handler 返回暴露所有已注册指标的 HTTP handler
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveHTTP 记录一次 HTTP 请求
+func ObserveHTTP(route, method, status string, seconds float64) {
+	httpRequests.WithLabelValues(route, method, status).Inc()
+	httpDuration.WithLabelValues(route, method, status).Observe(seconds)
+}
+
+// SetQueueDepth 设置队列当前积压数量
+func SetQueueDepth(queue string, depth int) {
+	queueDepth.WithLabelValues(queue).Set(float64(depth))
+}
+
+// IncAnalyses 分析次数加一，result 如 success、failure
+func IncAnalyses(result string) {
+	analyses.WithLabelValues(result).Inc()
+}
+
+// AddLLMTokens 累加模型消耗的 token 数
+func AddLLMTokens(model string, promptTokens, completionTokens int) {
+	llmTokens.WithLabelValues(model, "prompt").Add(float64(promptTokens))
+	llmTokens.WithLabelValues(model, "completion").Add(float64(completionTokens))
+}
@@ -0,0 +1,205 @@
+package openapi
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/response"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const resultSchema = "Result"
+
+// Build 根据已注册的路由生成 OpenAPI 文档，所有成功响应都包在 response.Result 信封中
+func (r *Registry) Build(info Info) *Document {
+	b := newSchemaBuilder()
+	b.components[resultSchema] = envelopeSchema()
+	b.components[resultSchema].Properties["pagination"] = b.schemaOf(reflect.TypeOf(response.Pagination{}))
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+	tags := make(map[string]bool)
+	for _, rt := range r.sortedRoutes() {
+		path, params := openAPIPath(rt.path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		op := b.operation(rt, params)
+		switch rt.method {
+		case http.MethodGet:
+			item.Get = op
+		case http.MethodPost:
+			item.Post = op
+		case http.MethodPut:
+			item.Put = op
+		case http.MethodPatch:
+			item.Patch = op
+		case http.MethodDelete:
+			item.Delete = op
+		}
+		for _, tag := range op.Tags {
+			if !tags[tag] {
+				tags[tag] = true
+				doc.Tags = append(doc.Tags, Tag{Name: tag})
+			}
+		}
+	}
+	doc.Components.Schemas = b.components
+	return doc
+}
+
+func (b *schemaBuilder) operation(rt route, pathParams []string) *Operation {
+	op := &Operation{
+		Tags:        rt.doc.Tags,
+		Summary:     rt.doc.Summary,
+		Description: rt.doc.Description,
+		OperationID: operationID(rt.method, rt.path),
+		Responses:   make(map[string]*Response),
+	}
+
+	declared := make(map[string]bool)
+	if t := typeOf(rt.doc.Path); t != nil {
+		for _, p := range b.parameters(t, "path", "uri") {
+			p.Required = true
+			declared[p.Name] = true
+			op.Parameters = append(op.Parameters, p)
+		}
+	}
+	for _, name := range pathParams {
+		if !declared[name] {
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	if t := typeOf(rt.doc.Query); t != nil {
+		op.Parameters = append(op.Parameters, b.parameters(t, "query", "form")...)
+	}
+	if t := typeOf(rt.doc.Body); t != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: b.schemaOf(t)}},
+		}
+	}
+
+	ok := &Schema{Ref: "#/components/schemas/" + resultSchema}
+	if t := typeOf(rt.doc.Response); t != nil {
+		ok = &Schema{AllOf: []*Schema{ok, {
+			Type:       "object",
+			Properties: map[string]*Schema{"data": b.dataSchema(t)},
+		}}}
+	}
+	op.Responses["200"] = &Response{
+		Description: "业务成功时 code 为 0",
+		Content:     map[string]*MediaType{"application/json": {Schema: ok}},
+	}
+	op.Responses["default"] = &Response{
+		Description: errorDescription(rt.doc.Errors),
+		Content: map[string]*MediaType{"application/json": {
+			Schema: &Schema{Ref: "#/components/schemas/" + resultSchema},
+		}},
+	}
+	return op
+}
+
+// dataSchema 与 response.WithData 保持一致：string、int、bool 会被包装为 {"result": value}
+func (b *schemaBuilder) dataSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.String, reflect.Int, reflect.Bool:
+		return &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{"result": primitive(t)},
+		}
+	}
+	return b.schemaOf(t)
+}
+
+func (b *schemaBuilder) parameters(t reflect.Type, in, tag string) []Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if field.Anonymous && name == "" {
+			// 嵌入结构体的字段与外层字段同级，与绑定时一致
+			params = append(params, b.parameters(field.Type, in, tag)...)
+			continue
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		params = append(params, Parameter{
+			Name:        name,
+			In:          in,
+			Description: field.Tag.Get("doc"),
+			Required:    isRequired(field),
+			Schema:      b.schemaOf(field.Type),
+		})
+	}
+	return params
+}
+
+func envelopeSchema() *Schema {
+	codes := errors.Codes()
+	enum := make([]any, 0, len(codes))
+	for _, code := range codes {
+		enum = append(enum, code)
+	}
+	return &Schema{
+		Type:        "object",
+		Description: "所有接口统一的响应信封",
+		Properties: map[string]*Schema{
+			"code": {Type: "integer", Description: "业务码，0 表示成功，完整列表见 /api/error-codes。\n\n" + codeTable(), Enum: enum},
+			"msg":  {Type: "string", Description: "与 code 对应的提示信息，按请求语言返回"},
+			"data": {Description: "业务数据，失败时可能携带错误详情"},
+			"cost": {Type: "string", Description: "服务端处理耗时，如 1.2ms"},
+		},
+		Required: []string{"code", "msg", "data", "cost"},
+	}
+}
+
+func codeTable() string {
+	var sb strings.Builder
+	sb.WriteString("| code | module | zh_CN | en |\n|---|---|---|---|\n")
+	for _, info := range errors.List() {
+		fmt.Fprintf(&sb, "| %d | %s | %s | %s |\n", info.Code, info.Module, info.Messages["zh_CN"], info.Messages["en"])
+	}
+	return sb.String()
+}
+
+func errorDescription(codes []int) string {
+	if len(codes) == 0 {
+		return "业务失败，见 Result.code"
+	}
+	en := errors.NewErrorText("en")
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%d %s", code, en.Text(code)))
+	}
+	return "业务失败，可能的 code：" + strings.Join(parts, "; ")
+}
+
+// operationID 由方法和路径生成，如 GET /api/jobs/:id -> getApiJobsById
+func operationID(method, path string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if seg[0] == ':' || seg[0] == '*' {
+			sb.WriteString("By")
+			seg = seg[1:]
+		}
+		for _, word := range strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return sb.String()
+}
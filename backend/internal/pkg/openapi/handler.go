@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+//go:embed swagger.html
+var swaggerHTML string
+
+// Handler 返回输出 Default 中路由文档的 handler，文档在第一次请求时生成，
+// 此时所有路由都已注册完毕
+func Handler(info Info) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  *Document
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			doc = Default.Build(info)
+		})
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// UIHandler 提供内嵌的 Swagger UI，需挂载在通配路由上，如 /api/docs/*any，
+// specURL 为 OpenAPI 文档地址
+func UIHandler(specURL string) gin.HandlerFunc {
+	index := strings.ReplaceAll(swaggerHTML, "{{SPEC_URL}}", specURL)
+	fileServer := http.FileServer(http.FS(swaggerFiles.FS))
+	return func(c *gin.Context) {
+		file := strings.TrimPrefix(c.Param("any"), "/")
+		if file == "" || file == "index.html" {
+			if !strings.HasSuffix(c.Request.URL.Path, "/") && file == "" {
+				c.Redirect(http.StatusMovedPermanently, c.Request.URL.Path+"/")
+				return
+			}
+			c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(index))
+			return
+		}
+		req := c.Request.Clone(c.Request.Context())
+		req.URL.Path = "/" + file
+		fileServer.ServeHTTP(c.Writer, req)
+	}
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"civ/internal/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jobPath struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+type jobQuery struct {
+	Status string `form:"status" doc:"filter by status"`
+}
+
+type jobBody struct {
+	Name   string     `json:"name" binding:"required"`
+	Branch string     `json:"branch,omitempty"`
+	Due    *time.Time `json:"due"`
+}
+
+type job struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	Parent *job   `json:"parent"`
+	Secret string `json:"-"`
+}
+
+func TestBuild(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := NewRegistry()
+	r := &RouterGroup{RouterGroup: gin.New().Group("/api"), registry: registry}
+	noop := func(c *gin.Context) {}
+
+	r.GET("/jobs/:id", Doc{Tags: []string{"jobs"}, Path: jobPath{}, Query: jobQuery{}, Response: job{}, Errors: []int{errors.JobDoesNotExist}}, noop)
+	r.POST("/jobs", Doc{Tags: []string{"jobs"}, Body: jobBody{}, Response: job{}}, noop)
+
+	doc := registry.Build(Info{Title: "test", Version: "v1"})
+
+	get := doc.Paths["/api/jobs/{id}"].Get
+	require.NotNil(t, get)
+	assert.Equal(t, "getApiJobsById", get.OperationID)
+	require.Len(t, get.Parameters, 2)
+	assert.Equal(t, Parameter{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "integer", Format: "int32"}}, get.Parameters[0])
+	assert.Equal(t, "query", get.Parameters[1].In)
+	assert.Contains(t, get.Responses["default"].Description, "10201")
+
+	data := get.Responses["200"].Content["application/json"].Schema.AllOf[1].Properties["data"]
+	assert.Equal(t, "#/components/schemas/job", data.Ref)
+	jobSchema := doc.Components.Schemas["job"]
+	assert.Equal(t, "#/components/schemas/job", jobSchema.Properties["parent"].Ref, "self references resolve to the component")
+	assert.NotContains(t, jobSchema.Properties, "Secret")
+
+	body := doc.Paths["/api/jobs"].Post.RequestBody.Content["application/json"].Schema
+	bodySchema := doc.Components.Schemas[body.Ref[len("#/components/schemas/"):]]
+	assert.Equal(t, []string{"name"}, bodySchema.Required)
+	assert.Equal(t, "date-time", bodySchema.Properties["due"].Format)
+	assert.True(t, bodySchema.Properties["due"].Nullable)
+
+	assert.Contains(t, doc.Components.Schemas, resultSchema)
+}
+
+func TestEmbeddedQueryFieldsAreParameters(t *testing.T) {
+	type pagedJobQuery struct {
+		jobQuery
+		Page int `form:"page"`
+	}
+	registry := NewRegistry()
+	r := &RouterGroup{RouterGroup: gin.New().Group("/api"), registry: registry}
+	r.GET("/jobs", Doc{Query: pagedJobQuery{}}, func(c *gin.Context) {})
+
+	params := registry.Build(Info{Title: "test", Version: "v1"}).Paths["/api/jobs"].Get.Parameters
+	require.Len(t, params, 2)
+	assert.Equal(t, "status", params[0].Name)
+	assert.Equal(t, "page", params[1].Name)
+}
+
+func TestBasicResponseIsWrapped(t *testing.T) {
+	registry := NewRegistry()
+	registry.add(http.MethodGet, "/api/hello", Doc{Response: ""})
+
+	doc := registry.Build(Info{})
+	data := doc.Paths["/api/hello"].Get.Responses["200"].Content["application/json"].Schema.AllOf[1].Properties["data"]
+	assert.Equal(t, "string", data.Properties["result"].Type)
+}
+
+func TestUIHandlerServesIndexAndAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/api/docs/*any", UIHandler("/api/openapi.json"))
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "/api/openapi.json"`)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs/swagger-ui-bundle.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotZero(t, w.Body.Len())
+}
@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Doc 描述一个接口，类型字段传入零值即可，如 Response: model.Job{}
+type Doc struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Path 路径参数结构体，字段使用 uri 标签；未提供时路径参数按字符串生成
+	Path any
+	// Query 查询参数结构体，字段使用 form 标签
+	Query any
+	// Body JSON 请求体
+	Body any
+	// Response 成功时响应信封中 data 字段的内容
+	Response any
+	// Errors 可能返回的业务错误码
+	Errors []int
+}
+
+type route struct {
+	method string
+	path   string
+	doc    Doc
+}
+
+// Registry 收集路由注册时附带的文档
+type Registry struct {
+	mu     sync.RWMutex
+	routes []route
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Default 路由注册使用的全局文档注册表
+var Default = NewRegistry()
+
+func (r *Registry) add(method, path string, doc Doc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route{method: method, path: path, doc: doc})
+}
+
+// RouterGroup 包装 gin.RouterGroup，注册路由的同时记录接口文档
+type RouterGroup struct {
+	*gin.RouterGroup
+	registry *Registry
+}
+
+// Wrap 返回记录到 Default 的 RouterGroup
+func Wrap(group *gin.RouterGroup) *RouterGroup {
+	return &RouterGroup{RouterGroup: group, registry: Default}
+}
+
+func (g *RouterGroup) Handle(method, relativePath string, doc Doc, handlers ...gin.HandlerFunc) {
+	g.RouterGroup.Handle(method, relativePath, handlers...)
+	g.registry.add(method, joinPath(g.BasePath(), relativePath), doc)
+}
+
+func (g *RouterGroup) GET(relativePath string, doc Doc, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodGet, relativePath, doc, handlers...)
+}
+
+func (g *RouterGroup) POST(relativePath string, doc Doc, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPost, relativePath, doc, handlers...)
+}
+
+func (g *RouterGroup) PUT(relativePath string, doc Doc, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPut, relativePath, doc, handlers...)
+}
+
+func (g *RouterGroup) PATCH(relativePath string, doc Doc, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodPatch, relativePath, doc, handlers...)
+}
+
+func (g *RouterGroup) DELETE(relativePath string, doc Doc, handlers ...gin.HandlerFunc) {
+	g.Handle(http.MethodDelete, relativePath, doc, handlers...)
+}
+
+// Group 创建子分组，子分组上的路由同样会被记录
+func (g *RouterGroup) Group(relativePath string, handlers ...gin.HandlerFunc) *RouterGroup {
+	return &RouterGroup{RouterGroup: g.RouterGroup.Group(relativePath, handlers...), registry: g.registry}
+}
+
+func joinPath(base, relative string) string {
+	p := strings.TrimRight(base, "/") + "/" + strings.TrimLeft(relative, "/")
+	if len(p) > 1 {
+		p = strings.TrimRight(p, "/")
+	}
+	return p
+}
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// openAPIPath 将 gin 路径 /jobs/:id 转换为 /jobs/{id}，并返回参数名
+func openAPIPath(path string) (string, []string) {
+	var names []string
+	for _, m := range ginParam.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return ginParam.ReplaceAllString(path, "{$1}"), names
+}
+
+func (r *Registry) sortedRoutes() []route {
+	r.mu.RLock()
+	routes := make([]route, len(r.routes))
+	copy(routes, r.routes)
+	r.mu.RUnlock()
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].path < routes[j].path
+	})
+	return routes
+}
+
+func typeOf(v any) reflect.Type {
+	if v == nil {
+		return nil
+	}
+	return reflect.TypeOf(v)
+}
@@ -0,0 +1,203 @@
+// Package protocompat 比较两个 descriptor set，找出破坏线上（二进制编码）兼容性的变更，
+// 用于在 proto 合约进入新版本前拦截不兼容的修改。
+//
+// 允许的变更：新增消息、字段、枚举值、服务和方法，重命名字段，删除字段或枚举值并保留（reserved）其编号。
+// 不兼容的变更：删除消息、枚举、服务或方法，删除字段或枚举值但未保留编号，复用保留编号，
+// 修改字段编码类型、repeated 属性或引用的消息类型，修改方法的请求、响应类型或流式属性。
+package protocompat
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// wireGroups 编码相同、可以互换的字段类型
+var wireGroups = map[descriptorpb.FieldDescriptorProto_Type]string{
+	descriptorpb.FieldDescriptorProto_TYPE_INT32:    "varint",
+	descriptorpb.FieldDescriptorProto_TYPE_INT64:    "varint",
+	descriptorpb.FieldDescriptorProto_TYPE_UINT32:   "varint",
+	descriptorpb.FieldDescriptorProto_TYPE_UINT64:   "varint",
+	descriptorpb.FieldDescriptorProto_TYPE_BOOL:     "varint",
+	descriptorpb.FieldDescriptorProto_TYPE_ENUM:     "varint",
+	descriptorpb.FieldDescriptorProto_TYPE_SINT32:   "zigzag",
+	descriptorpb.FieldDescriptorProto_TYPE_SINT64:   "zigzag",
+	descriptorpb.FieldDescriptorProto_TYPE_FIXED32:  "fixed32",
+	descriptorpb.FieldDescriptorProto_TYPE_SFIXED32: "fixed32",
+	descriptorpb.FieldDescriptorProto_TYPE_FLOAT:    "float",
+	descriptorpb.FieldDescriptorProto_TYPE_FIXED64:  "fixed64",
+	descriptorpb.FieldDescriptorProto_TYPE_SFIXED64: "fixed64",
+	descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:   "double",
+	descriptorpb.FieldDescriptorProto_TYPE_STRING:   "bytes",
+	descriptorpb.FieldDescriptorProto_TYPE_BYTES:    "bytes",
+	descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:  "message",
+	descriptorpb.FieldDescriptorProto_TYPE_GROUP:    "group",
+}
+
+type index struct {
+	messages map[string]*descriptorpb.DescriptorProto
+	enums    map[string]*descriptorpb.EnumDescriptorProto
+	services map[string]*descriptorpb.ServiceDescriptorProto
+}
+
+func newIndex(set *descriptorpb.FileDescriptorSet) *index {
+	idx := &index{
+		messages: make(map[string]*descriptorpb.DescriptorProto),
+		enums:    make(map[string]*descriptorpb.EnumDescriptorProto),
+		services: make(map[string]*descriptorpb.ServiceDescriptorProto),
+	}
+	for _, file := range set.GetFile() {
+		prefix := "." + file.GetPackage()
+		if file.GetPackage() == "" {
+			prefix = ""
+		}
+		for _, m := range file.GetMessageType() {
+			idx.addMessage(prefix, m)
+		}
+		for _, e := range file.GetEnumType() {
+			idx.enums[prefix+"."+e.GetName()] = e
+		}
+		for _, s := range file.GetService() {
+			idx.services[prefix+"."+s.GetName()] = s
+		}
+	}
+	return idx
+}
+
+func (idx *index) addMessage(prefix string, m *descriptorpb.DescriptorProto) {
+	name := prefix + "." + m.GetName()
+	idx.messages[name] = m
+	for _, nested := range m.GetNestedType() {
+		idx.addMessage(name, nested)
+	}
+	for _, e := range m.GetEnumType() {
+		idx.enums[name+"."+e.GetName()] = e
+	}
+}
+
+// Check 返回 current 相对 baseline 的不兼容变更，按名称排序，兼容时为空
+func Check(baseline, current *descriptorpb.FileDescriptorSet) []string {
+	old, cur := newIndex(baseline), newIndex(current)
+	var changes []string
+	report := func(format string, args ...any) {
+		changes = append(changes, fmt.Sprintf(format, args...))
+	}
+
+	for name, m := range old.messages {
+		next, ok := cur.messages[name]
+		if !ok {
+			report("message %s removed", name)
+			continue
+		}
+		checkMessage(name, m, next, report)
+	}
+	for name, e := range old.enums {
+		next, ok := cur.enums[name]
+		if !ok {
+			report("enum %s removed", name)
+			continue
+		}
+		checkEnum(name, e, next, report)
+	}
+	for name, s := range old.services {
+		next, ok := cur.services[name]
+		if !ok {
+			report("service %s removed", name)
+			continue
+		}
+		checkService(name, s, next, report)
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func checkMessage(name string, old, cur *descriptorpb.DescriptorProto, report func(string, ...any)) {
+	fields := make(map[int32]*descriptorpb.FieldDescriptorProto, len(cur.GetField()))
+	for _, f := range cur.GetField() {
+		fields[f.GetNumber()] = f
+		if reservedField(old, f.GetNumber()) {
+			report("field %s.%s reuses reserved number %d", name, f.GetName(), f.GetNumber())
+		}
+	}
+	for _, f := range old.GetField() {
+		next, ok := fields[f.GetNumber()]
+		if !ok {
+			if !reservedField(cur, f.GetNumber()) {
+				report("field %s.%s (%d) removed without reserving its number", name, f.GetName(), f.GetNumber())
+			}
+			continue
+		}
+		if wireGroups[f.GetType()] != wireGroups[next.GetType()] {
+			report("field %s.%s (%d) changed type from %s to %s", name, f.GetName(), f.GetNumber(), typeName(f), typeName(next))
+		} else if f.GetTypeName() != next.GetTypeName() && f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			report("field %s.%s (%d) changed message type from %s to %s", name, f.GetName(), f.GetNumber(), f.GetTypeName(), next.GetTypeName())
+		}
+		if repeated(f) != repeated(next) {
+			report("field %s.%s (%d) changed cardinality", name, f.GetName(), f.GetNumber())
+		}
+	}
+}
+
+func checkEnum(name string, old, cur *descriptorpb.EnumDescriptorProto, report func(string, ...any)) {
+	values := make(map[int32]bool, len(cur.GetValue()))
+	for _, v := range cur.GetValue() {
+		values[v.GetNumber()] = true
+	}
+	for _, v := range old.GetValue() {
+		if values[v.GetNumber()] {
+			continue
+		}
+		reserved := false
+		for _, r := range cur.GetReservedRange() {
+			// 枚举的保留范围包含 end
+			if v.GetNumber() >= r.GetStart() && v.GetNumber() <= r.GetEnd() {
+				reserved = true
+			}
+		}
+		if !reserved {
+			report("enum value %s.%s (%d) removed without reserving its number", name, v.GetName(), v.GetNumber())
+		}
+	}
+}
+
+func checkService(name string, old, cur *descriptorpb.ServiceDescriptorProto, report func(string, ...any)) {
+	methods := make(map[string]*descriptorpb.MethodDescriptorProto, len(cur.GetMethod()))
+	for _, m := range cur.GetMethod() {
+		methods[m.GetName()] = m
+	}
+	for _, m := range old.GetMethod() {
+		next, ok := methods[m.GetName()]
+		switch {
+		case !ok:
+			report("method %s.%s removed", name, m.GetName())
+		case m.GetInputType() != next.GetInputType():
+			report("method %s.%s changed request type from %s to %s", name, m.GetName(), m.GetInputType(), next.GetInputType())
+		case m.GetOutputType() != next.GetOutputType():
+			report("method %s.%s changed response type from %s to %s", name, m.GetName(), m.GetOutputType(), next.GetOutputType())
+		case m.GetClientStreaming() != next.GetClientStreaming() || m.GetServerStreaming() != next.GetServerStreaming():
+			report("method %s.%s changed streaming mode", name, m.GetName())
+		}
+	}
+}
+
+// reservedField 判断编号是否在消息的保留范围内，消息的保留范围不包含 end
+func reservedField(m *descriptorpb.DescriptorProto, number int32) bool {
+	for _, r := range m.GetReservedRange() {
+		if number >= r.GetStart() && number < r.GetEnd() {
+			return true
+		}
+	}
+	return false
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) bool {
+	return f.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+}
+
+func typeName(f *descriptorpb.FieldDescriptorProto) string {
+	if f.GetTypeName() != "" {
+		return f.GetTypeName()
+	}
+	return f.GetType().String()
+}
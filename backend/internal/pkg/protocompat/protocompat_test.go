@@ -0,0 +1,96 @@
+package protocompat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   typ.Enum(),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+}
+
+func baseline() *descriptorpb.FileDescriptorSet {
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("demo/v1/demo.proto"),
+		Package: proto.String("demo.v1"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				field("log", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			}},
+			{Name: proto.String("Reply")},
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("STATUS_OK"), Number: proto.Int32(1)},
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Demo"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Run"),
+				InputType:  proto.String(".demo.v1.Request"),
+				OutputType: proto.String(".demo.v1.Reply"),
+			}},
+		}},
+	}}}
+}
+
+func TestCheckAllowsCompatibleChanges(t *testing.T) {
+	current := baseline()
+	file := current.File[0]
+	request := file.MessageType[0]
+	// 新增字段、重命名字段、int64 改为 uint64、删除字段并保留编号
+	request.Field[0].Name = proto.String("analysis_id")
+	request.Field[0].Type = descriptorpb.FieldDescriptorProto_TYPE_UINT64.Enum()
+	request.Field = append(request.Field[:1], field("model", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING))
+	request.ReservedRange = []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(2), End: proto.Int32(3)}}
+	file.MessageType = append(file.MessageType, &descriptorpb.DescriptorProto{Name: proto.String("Extra")})
+	file.Service[0].Method = append(file.Service[0].Method, &descriptorpb.MethodDescriptorProto{
+		Name: proto.String("Ping"), InputType: proto.String(".demo.v1.Reply"), OutputType: proto.String(".demo.v1.Reply"),
+	})
+
+	assert.Empty(t, Check(baseline(), current))
+}
+
+func TestCheckReportsBreakingChanges(t *testing.T) {
+	current := baseline()
+	file := current.File[0]
+	request := file.MessageType[0]
+	request.Field[0].Type = descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	request.Field[1].Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	file.MessageType = file.MessageType[:1]
+	file.EnumType[0].Value = file.EnumType[0].Value[:1]
+	file.Service[0].Method[0].ServerStreaming = proto.Bool(true)
+
+	assert.Equal(t, []string{
+		"enum value .demo.v1.Status.STATUS_OK (1) removed without reserving its number",
+		"field .demo.v1.Request.id (1) changed type from TYPE_INT64 to TYPE_STRING",
+		"field .demo.v1.Request.log (2) changed cardinality",
+		"message .demo.v1.Reply removed",
+		"method .demo.v1.Demo.Run changed streaming mode",
+	}, Check(baseline(), current))
+}
+
+func TestCheckReportsRemovedAndReusedFields(t *testing.T) {
+	old := baseline()
+	old.File[0].MessageType[0].ReservedRange = []*descriptorpb.DescriptorProto_ReservedRange{{Start: proto.Int32(5), End: proto.Int32(6)}}
+	current := proto.Clone(old).(*descriptorpb.FileDescriptorSet)
+	request := current.File[0].MessageType[0]
+	request.Field = []*descriptorpb.FieldDescriptorProto{request.Field[0], field("retry", 5, descriptorpb.FieldDescriptorProto_TYPE_BOOL)}
+
+	assert.Equal(t, []string{
+		"field .demo.v1.Request.log (2) removed without reserving its number",
+		"field .demo.v1.Request.retry reuses reserved number 5",
+	}, Check(old, current))
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"civ/internal/pkg/response"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// cursor 对客户端不透明，目前只记录偏移量，后续可换成基于排序键的游标而不影响调用方
+type cursor struct {
+	Offset int `json:"o"`
+}
+
+func encodeCursor(offset int) string {
+	b, _ := json.Marshal(cursor{Offset: offset})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return 0, err
+	}
+	if c.Offset < 0 {
+		return 0, &Error{Param: ParamCursor, Reason: "negative offset"}
+	}
+	return c.Offset, nil
+}
+
+// Pagination 根据总数生成分页信息，链接在 u 的基础上只替换分页参数
+func (s *Spec) Pagination(u *url.URL, total int64) *response.Pagination {
+	p := &response.Pagination{
+		Total: total,
+		Page:  s.offset/s.Size + 1,
+		Size:  s.Size,
+		Links: response.Links{Self: u.RequestURI()},
+	}
+	hasNext := int64(s.offset+s.Size) < total
+	if hasNext {
+		p.NextCursor = encodeCursor(s.offset + s.Size)
+	}
+
+	if s.Cursor {
+		if hasNext {
+			p.Links.Next = link(u, ParamCursor, p.NextCursor)
+		}
+		if s.offset > 0 {
+			p.Links.Prev = link(u, ParamCursor, encodeCursor(max(s.offset-s.Size, 0)))
+		}
+		return p
+	}
+
+	p.Links.First = link(u, ParamPage, "1")
+	if hasNext {
+		p.Links.Next = link(u, ParamPage, strconv.Itoa(s.Page+1))
+	}
+	if s.Page > 1 {
+		p.Links.Prev = link(u, ParamPage, strconv.Itoa(s.Page-1))
+	}
+	if total > 0 {
+		last := (total + int64(s.Size) - 1) / int64(s.Size)
+		p.Links.Last = link(u, ParamPage, strconv.FormatInt(last, 10))
+	}
+	return p
+}
+
+func link(u *url.URL, param, value string) string {
+	values := u.Query()
+	values.Del(ParamPage)
+	values.Del(ParamCursor)
+	values.Set(param, value)
+	next := url.URL{Path: u.Path, RawQuery: values.Encode()}
+	return next.RequestURI()
+}
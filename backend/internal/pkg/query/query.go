@@ -0,0 +1,281 @@
+// Package query 解析列表接口通用的分页、排序和过滤参数，并按资源白名单转换为 GORM scope。
+//
+// 支持的查询参数：
+//
+//	page=2&size=20            页码分页，page 从 1 开始
+//	cursor=<next_cursor>      游标分页，游标取自上一页响应的 pagination.next_cursor，优先于 page
+//	sort=-finished_at,id      排序，"-" 前缀表示降序
+//	status=failed             等值过滤，逗号分隔表示 IN：status=failed,canceled
+//	finished_at_from=2026-10-01&finished_at_to=2026-10-19
+//	                          时间/整数字段的范围过滤，仅日期的 _to 包含当天
+//
+// 只有 Rules 中声明的字段会进入 SQL，列名由白名单给出而不是来自请求。
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ParamPage   = "page"
+	ParamSize   = "size"
+	ParamCursor = "cursor"
+	ParamSort   = "sort"
+
+	rangeFrom = "_from"
+	rangeTo   = "_to"
+
+	defaultSize    = 20
+	defaultMaxSize = 100
+)
+
+// FieldType 决定过滤值的解析方式
+type FieldType int
+
+const (
+	String FieldType = iota
+	Int
+	Time
+)
+
+// Field 允许过滤的字段，Int 和 Time 类型额外支持 _from/_to 范围过滤
+type Field struct {
+	Column string
+	Type   FieldType
+}
+
+// Rules 某个资源允许的过滤和排序字段
+type Rules struct {
+	// Filters 查询参数名到字段的映射
+	Filters map[string]Field
+	// Sorts sort 参数中可用的名称到列名的映射
+	Sorts map[string]string
+	// DefaultSort 未指定 sort 时使用，格式同 sort 参数，如 "-id"
+	DefaultSort string
+	// TieBreaker 追加在排序末尾保证翻页稳定的唯一列，默认 id
+	TieBreaker string
+	// DefaultSize 和 MaxSize 默认 20 和 100
+	DefaultSize int
+	MaxSize     int
+}
+
+// Error 查询参数不合法，Param 为出错的参数名
+type Error struct {
+	Param  string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid query parameter %q: %s", e.Param, e.Reason)
+}
+
+type order struct {
+	column string
+	desc   bool
+}
+
+// Spec 解析后的列表查询
+type Spec struct {
+	Page   int
+	Size   int
+	Cursor bool
+
+	offset  int
+	orders  []order
+	filters []clause.Expression
+}
+
+// Parse 按 rules 解析查询参数，未在白名单中的参数被忽略，白名单字段的非法值返回 *Error
+func Parse(values url.Values, rules Rules) (*Spec, error) {
+	spec := &Spec{Page: 1, Size: rules.DefaultSize}
+	if spec.Size <= 0 {
+		spec.Size = defaultSize
+	}
+	maxSize := rules.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+
+	if v := values.Get(ParamSize); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 || size > maxSize {
+			return nil, &Error{Param: ParamSize, Reason: fmt.Sprintf("must be an integer between 1 and %d", maxSize)}
+		}
+		spec.Size = size
+	}
+	if v := values.Get(ParamCursor); v != "" {
+		offset, err := decodeCursor(v)
+		if err != nil {
+			return nil, &Error{Param: ParamCursor, Reason: "malformed cursor"}
+		}
+		spec.Cursor = true
+		spec.offset = offset
+	} else if v := values.Get(ParamPage); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 {
+			return nil, &Error{Param: ParamPage, Reason: "must be a positive integer"}
+		}
+		spec.Page = page
+		spec.offset = (page - 1) * spec.Size
+	}
+
+	sorts := values.Get(ParamSort)
+	if sorts == "" {
+		sorts = rules.DefaultSort
+	}
+	if err := spec.parseSort(sorts, rules); err != nil {
+		return nil, err
+	}
+	if err := spec.parseFilters(values, rules); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *Spec) parseSort(sorts string, rules Rules) error {
+	tieBreaker := rules.TieBreaker
+	if tieBreaker == "" {
+		tieBreaker = "id"
+	}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(sorts, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		column, ok := rules.Sorts[name]
+		if !ok {
+			return &Error{Param: ParamSort, Reason: fmt.Sprintf("cannot sort by %q", name)}
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		s.orders = append(s.orders, order{column: column, desc: desc})
+	}
+	if !seen[tieBreaker] {
+		desc := len(s.orders) > 0 && s.orders[len(s.orders)-1].desc
+		s.orders = append(s.orders, order{column: tieBreaker, desc: desc})
+	}
+	return nil
+}
+
+func (s *Spec) parseFilters(values url.Values, rules Rules) error {
+	params := make([]string, 0, len(rules.Filters))
+	for param := range rules.Filters {
+		params = append(params, param)
+	}
+	// 固定顺序，使生成的 SQL 稳定
+	sort.Strings(params)
+	for _, param := range params {
+		field := rules.Filters[param]
+		col := clause.Column{Name: field.Column}
+		if raw := values.Get(param); raw != "" {
+			var parsed []any
+			for _, v := range strings.Split(raw, ",") {
+				value, err := parseValue(strings.TrimSpace(v), field.Type, false)
+				if err != nil {
+					return &Error{Param: param, Reason: err.Error()}
+				}
+				parsed = append(parsed, value)
+			}
+			if len(parsed) == 1 {
+				s.filters = append(s.filters, clause.Eq{Column: col, Value: parsed[0]})
+			} else {
+				s.filters = append(s.filters, clause.IN{Column: col, Values: parsed})
+			}
+		}
+		if field.Type == String {
+			continue
+		}
+		if raw := values.Get(param + rangeFrom); raw != "" {
+			value, err := parseValue(raw, field.Type, false)
+			if err != nil {
+				return &Error{Param: param + rangeFrom, Reason: err.Error()}
+			}
+			s.filters = append(s.filters, clause.Gte{Column: col, Value: value})
+		}
+		if raw := values.Get(param + rangeTo); raw != "" {
+			value, err := parseValue(raw, field.Type, true)
+			if err != nil {
+				return &Error{Param: param + rangeTo, Reason: err.Error()}
+			}
+			if t, ok := value.(time.Time); ok && isDate(raw) {
+				s.filters = append(s.filters, clause.Lt{Column: col, Value: t})
+			} else {
+				s.filters = append(s.filters, clause.Lte{Column: col, Value: value})
+			}
+		}
+	}
+	return nil
+}
+
+// parseValue 解析过滤值；upper 为 true 时仅日期的值取次日零点，配合 < 使区间包含当天
+func parseValue(raw string, typ FieldType, upper bool) (any, error) {
+	switch typ {
+	case Int:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", raw)
+		}
+		return n, nil
+	case Time:
+		if isDate(raw) {
+			t, err := time.ParseInLocation(time.DateOnly, raw, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid date", raw)
+			}
+			if upper {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date (2006-01-02) or RFC 3339 time", raw)
+		}
+		return t, nil
+	default:
+		return raw, nil
+	}
+}
+
+func isDate(raw string) bool {
+	return len(raw) == len(time.DateOnly)
+}
+
+// Offset 当前页跳过的记录数
+func (s *Spec) Offset() int {
+	return s.offset
+}
+
+// Filter 追加过滤条件的 scope，统计总数时单独使用
+func (s *Spec) Filter(db *gorm.DB) *gorm.DB {
+	for _, expr := range s.filters {
+		db = db.Where(expr)
+	}
+	return db
+}
+
+// Sort 追加排序的 scope
+func (s *Spec) Sort(db *gorm.DB) *gorm.DB {
+	for _, o := range s.orders {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: o.column}, Desc: o.desc})
+	}
+	return db
+}
+
+// Paginate 追加 limit/offset 的 scope
+func (s *Spec) Paginate(db *gorm.DB) *gorm.DB {
+	return db.Offset(s.offset).Limit(s.Size)
+}
@@ -0,0 +1,93 @@
+package query
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type row struct {
+	ID         uint
+	Status     string
+	Branch     string
+	FinishedAt string
+}
+
+var rules = Rules{
+	Filters: map[string]Field{
+		"status":      {Column: "status"},
+		"branch":      {Column: "branch"},
+		"finished_at": {Column: "finished_at", Type: Time},
+	},
+	Sorts:       map[string]string{"id": "id", "finished_at": "finished_at"},
+	DefaultSort: "-id",
+}
+
+func toSQL(t *testing.T, spec *Spec) string {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{DryRun: true})
+	require.NoError(t, err)
+	return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&row{}).Scopes(spec.Filter, spec.Sort, spec.Paginate).Find(&[]row{})
+	})
+}
+
+func TestParseBuildsWhitelistedScopes(t *testing.T) {
+	values, _ := url.ParseQuery("status=failed,canceled&branch=main&finished_at_from=2026-10-01&finished_at_to=2026-10-19" +
+		"&sort=-finished_at&page=3&size=10&unknown=1")
+	spec, err := Parse(values, rules)
+	require.NoError(t, err)
+	assert.Equal(t, 20, spec.Offset())
+
+	sql := toSQL(t, spec)
+	assert.Contains(t, sql, "`branch` = \"main\"")
+	assert.Contains(t, sql, "`status` IN (\"failed\",\"canceled\")")
+	assert.Contains(t, sql, "`finished_at` >= \"2026-10-01")
+	assert.Contains(t, sql, "`finished_at` < \"2026-10-20", "date-only upper bound includes the whole day")
+	assert.Contains(t, sql, "ORDER BY `finished_at` DESC,`id` DESC LIMIT 10 OFFSET 20")
+	assert.NotContains(t, sql, "unknown")
+}
+
+func TestParseRejectsInvalidParameters(t *testing.T) {
+	cases := map[string]string{
+		"sort=password":          ParamSort,
+		"size=1000":              ParamSize,
+		"page=0":                 ParamPage,
+		"cursor=!!":              ParamCursor,
+		"finished_at_from=today": "finished_at_from",
+	}
+	for raw, param := range cases {
+		values, _ := url.ParseQuery(raw)
+		_, err := Parse(values, rules)
+		var queryErr *Error
+		require.ErrorAs(t, err, &queryErr, raw)
+		assert.Equal(t, param, queryErr.Param, raw)
+	}
+}
+
+func TestPaginationLinks(t *testing.T) {
+	u, _ := url.Parse("/api/jobs?status=failed&page=2&size=10")
+	spec, err := Parse(u.Query(), rules)
+	require.NoError(t, err)
+
+	p := spec.Pagination(u, 45)
+	assert.Equal(t, int64(45), p.Total)
+	assert.Equal(t, 2, p.Page)
+	assert.Equal(t, "/api/jobs?page=3&size=10&status=failed", p.Links.Next)
+	assert.Equal(t, "/api/jobs?page=1&size=10&status=failed", p.Links.Prev)
+	assert.Equal(t, "/api/jobs?page=5&size=10&status=failed", p.Links.Last)
+	require.NotEmpty(t, p.NextCursor)
+
+	u, _ = url.Parse("/api/jobs?size=10&cursor=" + p.NextCursor)
+	spec, err = Parse(u.Query(), rules)
+	require.NoError(t, err)
+	assert.Equal(t, 20, spec.Offset())
+	p = spec.Pagination(u, 25)
+	assert.Empty(t, p.NextCursor)
+	assert.Empty(t, p.Links.Next)
+	assert.Equal(t, "/api/jobs?cursor="+encodeCursor(10)+"&size=10", p.Links.Prev)
+}
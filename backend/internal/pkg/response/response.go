@@ -1,28 +1,46 @@
 package response
 
 import (
-	"civ/config"
 	"civ/internal/pkg/errors"
+	"civ/internal/pkg/i18n"
 	"github.com/gin-gonic/gin"
-	"net/http"
 	"time"
 )
 
 type Result struct {
-	Code int         `json:"code"`
-	Msg  string      `json:"msg"`
-	Data interface{} `json:"data"`
-	Cost string      `json:"cost"`
+	Code       int         `json:"code"`
+	Msg        string      `json:"msg"`
+	Data       interface{} `json:"data"`
+	Cost       string      `json:"cost"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination 列表接口的分页信息，data 为当前页的记录
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	NextCursor string `json:"next_cursor,omitempty" doc:"下一页游标，没有更多数据时为空"`
+	Links      Links  `json:"links"`
+}
+
+// Links 翻页链接，均为带查询参数的相对地址
+type Links struct {
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
 }
 
 type Response struct {
+	// httpCode 为 0 时按 code 取 errors.HTTPStatus
 	httpCode int
 	result   *Result
 }
 
 func Resp() *Response {
 	return &Response{
-		httpCode: http.StatusOK,
 		result: &Result{
 			Code: 0,
 			Msg:  "",
@@ -64,13 +82,21 @@ func (r *Response) WithDataSuccess(c *gin.Context, data any) {
 	r.json(c)
 }
 
+// PageSuccess 列表接口成功返回
+func (r *Response) PageSuccess(c *gin.Context, items any, pagination *Pagination) {
+	r.SetCode(errors.SUCCESS)
+	r.WithData(items)
+	r.WithPagination(pagination)
+	r.json(c)
+}
+
 // SetCode 设置返回code码
 func (r *Response) SetCode(code int) *Response {
 	r.result.Code = code
 	return r
 }
 
-// SetHttpCode 设置http状态码
+// SetHttpCode 设置http状态码，不设置时使用错误码登记的状态码
 func (r *Response) SetHttpCode(code int) *Response {
 	r.httpCode = code
 	return r
@@ -91,18 +117,25 @@ func (r *Response) WithData(data any) *Response {
 	return r
 }
 
+// WithPagination 设置分页信息
+func (r *Response) WithPagination(pagination *Pagination) *Response {
+	r.result.Pagination = pagination
+	return r
+}
+
 // SetMessage 设置返回自定义错误消息
 func (r *Response) SetMessage(message string) *Response {
 	r.result.Msg = message
 	return r
 }
 
-var ErrorText = errors.NewErrorText(config.GetConfig().System.Language)
-
 // json 返回 gin 框架的 HandlerFunc
 func (r *Response) json(c *gin.Context) {
 	if r.result.Msg == "" {
-		r.result.Msg = ErrorText.Text(r.result.Code)
+		r.result.Msg = errors.NewErrorText(i18n.FromGin(c)).Text(r.result.Code)
+	}
+	if r.httpCode == 0 {
+		r.httpCode = errors.HTTPStatus(r.result.Code)
 	}
 	r.result.Cost = time.Since(c.GetTime("requestStartTime")).String()
 	c.AbortWithStatusJSON(r.httpCode, r.result)
@@ -117,6 +150,11 @@ func Success(c *gin.Context, data ...any) {
 	Resp().Success(c)
 }
 
+// PageSuccess 列表业务成功响应
+func PageSuccess(c *gin.Context, items any, pagination *Pagination) {
+	Resp().PageSuccess(c, items, pagination)
+}
+
 // FailCode 业务失败响应
 func FailCode(c *gin.Context, code int, data ...any) {
 	if data != nil {
@@ -0,0 +1,234 @@
+// Package tlsconfig 根据 autoload.TLSConfig 构造 gRPC 客户端和服务端的 TLS 配置。
+//
+// 证书、私钥和 CA 文件在握手时按修改时间检查（最多每 CheckInterval 一次），被替换后自动加载，
+// 证书轮换无需重启。新文件无法解析时继续使用旧证书并记录警告。
+package tlsconfig
+
+import (
+	"civ/config/autoload"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// CheckInterval 两次检查证书文件是否变化的最小间隔
+var CheckInterval = 10 * time.Second
+
+// Client 返回客户端 TLS 配置。设置了 CAFile 时用它校验服务端证书，否则使用系统根证书；
+// 设置了 CertFile/KeyFile 时向服务端出示客户端证书（mTLS）。address 用于推断 ServerName
+func Client(cfg autoload.TLSConfig, address string) (*tls.Config, error) {
+	if err := checkPair(cfg); err != nil {
+		return nil, err
+	}
+	serverName := cfg.ServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("tls: cannot infer server_name from address %q: %w", address, err)
+		}
+		serverName = host
+	}
+	conf := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: serverName}
+
+	if cfg.CertFile != "" {
+		cert, err := newFileCert(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return cert.get()
+		}
+	}
+	if cfg.CAFile != "" {
+		ca, err := newFilePool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		// 标准校验不支持动态 RootCAs，改为在 VerifyConnection 中用当前 CA 校验
+		conf.InsecureSkipVerify = true
+		conf.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyServer(state, ca.get(), serverName)
+		}
+	}
+	return conf, nil
+}
+
+// Server 返回服务端 TLS 配置，CertFile/KeyFile 必填；设置了 CAFile 时要求并校验客户端证书（mTLS）
+func Server(cfg autoload.TLSConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("tls: cert_file and key_file are required to serve TLS")
+	}
+	cert, err := newFileCert(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var ca *filePool
+	if cfg.CAFile != "" {
+		if ca, err = newFilePool(cfg.CAFile); err != nil {
+			return nil, err
+		}
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			current, err := cert.get()
+			if err != nil {
+				return nil, err
+			}
+			conf := &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{*current}}
+			if ca != nil {
+				conf.ClientCAs = ca.get()
+				conf.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return conf, nil
+		},
+	}, nil
+}
+
+func checkPair(cfg autoload.TLSConfig) error {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return errors.New("tls: cert_file and key_file must be set together")
+	}
+	return nil
+}
+
+func verifyServer(state tls.ConnectionState, roots *x509.CertPool, serverName string) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("tls: server presented no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       serverName,
+	})
+	return err
+}
+
+// watched 按修改时间判断文件是否需要重新加载
+type watched struct {
+	files   []string
+	mu      sync.Mutex
+	modTime time.Time
+	checked time.Time
+}
+
+// changed 返回文件自上次加载后是否有变化，距上次检查不足 CheckInterval 时返回 false
+func (w *watched) changed() bool {
+	now := time.Now()
+	if now.Sub(w.checked) < CheckInterval {
+		return false
+	}
+	w.checked = now
+	latest, err := latestModTime(w.files)
+	if err != nil {
+		slog.Warn("tls: stat certificate files failed, keeping current", "files", w.files, "error", err)
+		return false
+	}
+	return !latest.Equal(w.modTime)
+}
+
+func latestModTime(files []string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+type fileCert struct {
+	watched
+	cert *tls.Certificate
+}
+
+func newFileCert(certFile, keyFile string) (*fileCert, error) {
+	f := &fileCert{watched: watched{files: []string{certFile, keyFile}}}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fileCert) load() error {
+	modTime, err := latestModTime(f.files)
+	if err != nil {
+		return fmt.Errorf("tls: read certificate: %w", err)
+	}
+	cert, err := tls.LoadX509KeyPair(f.files[0], f.files[1])
+	if err != nil {
+		return fmt.Errorf("tls: load key pair cert_file=%s key_file=%s: %w", f.files[0], f.files[1], err)
+	}
+	f.cert, f.modTime, f.checked = &cert, modTime, time.Now()
+	return nil
+}
+
+func (f *fileCert) get() (*tls.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.changed() {
+		if err := f.load(); err != nil {
+			slog.Warn("tls: reload certificate failed, keeping current", "error", err)
+		} else {
+			slog.Info("tls: certificate reloaded", "cert_file", f.files[0])
+		}
+	}
+	return f.cert, nil
+}
+
+type filePool struct {
+	watched
+	pool *x509.CertPool
+}
+
+func newFilePool(caFile string) (*filePool, error) {
+	f := &filePool{watched: watched{files: []string{caFile}}}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *filePool) load() error {
+	modTime, err := latestModTime(f.files)
+	if err != nil {
+		return fmt.Errorf("tls: read ca_file: %w", err)
+	}
+	pem, err := os.ReadFile(f.files[0])
+	if err != nil {
+		return fmt.Errorf("tls: read ca_file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("tls: no PEM certificates found in ca_file %s", f.files[0])
+	}
+	f.pool, f.modTime, f.checked = pool, modTime, time.Now()
+	return nil
+}
+
+func (f *filePool) get() *x509.CertPool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.changed() {
+		if err := f.load(); err != nil {
+			slog.Warn("tls: reload ca_file failed, keeping current", "error", err)
+		} else {
+			slog.Info("tls: ca_file reloaded", "ca_file", f.files[0])
+		}
+	}
+	return f.pool
+}
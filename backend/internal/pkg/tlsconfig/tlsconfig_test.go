@@ -0,0 +1,167 @@
+package tlsconfig
+
+import (
+	"civ/config/autoload"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newAuthority(t *testing.T, name string) *authority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &authority{cert: cert, key: key}
+}
+
+// issue 签发证书并写入 dir/<name>.crt 和 dir/<name>.key
+func (a *authority) issue(t *testing.T, dir, name string, usage x509.ExtKeyUsage) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, a.cert, &key.PublicKey, a.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func (a *authority) write(t *testing.T, dir, name string) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.cert.Raw}), 0o600))
+	return file
+}
+
+func serve(t *testing.T, cfg autoload.TLSConfig) string {
+	t.Helper()
+	serverTLS, err := Server(cfg)
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS)))
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+	return net.JoinHostPort("localhost", port)
+}
+
+func check(t *testing.T, address string, cfg autoload.TLSConfig) error {
+	t.Helper()
+	clientTLS, err := Client(cfg, address)
+	require.NoError(t, err)
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newAuthority(t, "civ test ca")
+	caFile := ca.write(t, dir, "ca.crt")
+	serverCert, serverKey := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "client", x509.ExtKeyUsageClientAuth)
+
+	address := serve(t, autoload.TLSConfig{Enabled: true, CertFile: serverCert, KeyFile: serverKey, CAFile: caFile})
+
+	assert.NoError(t, check(t, address, autoload.TLSConfig{Enabled: true, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}))
+	assert.Error(t, check(t, address, autoload.TLSConfig{Enabled: true, CAFile: caFile}), "server requires a client certificate")
+
+	other := newAuthority(t, "other ca")
+	assert.Error(t, check(t, address, autoload.TLSConfig{Enabled: true, CAFile: other.write(t, dir, "other.crt"),
+		CertFile: clientCert, KeyFile: clientKey}), "server certificate is not signed by the configured CA")
+}
+
+func TestCertificateReload(t *testing.T) {
+	previous := CheckInterval
+	CheckInterval = 0
+	t.Cleanup(func() { CheckInterval = previous })
+
+	dir := t.TempDir()
+	ca := newAuthority(t, "civ test ca")
+	certFile, keyFile := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	cert, err := newFileCert(certFile, keyFile)
+	require.NoError(t, err)
+	first, _ := cert.get()
+
+	ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	second, _ := cert.get()
+	assert.NotEqual(t, first.Certificate[0], second.Certificate[0], "rotated certificate is picked up")
+
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0o600))
+	later = later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	third, _ := cert.get()
+	assert.Equal(t, second.Certificate[0], third.Certificate[0], "broken files keep the current certificate")
+}
+
+func TestMisconfiguration(t *testing.T) {
+	_, err := Client(autoload.TLSConfig{Enabled: true, CertFile: "client.crt"}, "agent:50051")
+	assert.EqualError(t, err, "tls: cert_file and key_file must be set together")
+
+	_, err = Server(autoload.TLSConfig{Enabled: true})
+	assert.EqualError(t, err, "tls: cert_file and key_file are required to serve TLS")
+
+	_, err = Client(autoload.TLSConfig{Enabled: true, CAFile: "/nonexistent/ca.crt"}, "agent:50051")
+	assert.ErrorContains(t, err, "/nonexistent/ca.crt")
+
+	empty := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	_, err = Client(autoload.TLSConfig{Enabled: true, CAFile: empty}, "agent:50051")
+	assert.ErrorContains(t, err, "no PEM certificates found in ca_file")
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"civ/config/autoload"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitExportsToCollector(t *testing.T) {
+	received := make(chan int, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/v1/traces" {
+			received <- len(body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	shutdown, err := Init(context.Background(), autoload.TraceConfig{
+		Enabled:     true,
+		ServiceName: "civ-test",
+		Endpoint:    strings.TrimPrefix(collector.URL, "http://"),
+		Insecure:    true,
+	})
+	require.NoError(t, err)
+
+	ctx, span := Tracer().Start(context.Background(), "test-span")
+	assert.NotEmpty(t, TraceID(ctx))
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	select {
+	case n := <-received:
+		assert.Positive(t, n, "collector should receive a non-empty export request")
+	default:
+		t.Fatal("collector stub did not receive any spans")
+	}
+}
@@ -0,0 +1,139 @@
+// Package validation translates gin binding failures into localized,
+// field-level errors.
+package validation
+
+import (
+	"civ/internal/pkg/i18n"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+var (
+	once        sync.Once
+	initErr     error
+	translators = make(map[string]ut.Translator)
+)
+
+// Init 在 gin 的校验器上注册中英文翻译，并让错误中的字段名使用 json/form/uri 标签名。
+// 需在处理请求前调用（校验器会缓存结构体信息），可重复调用，只会初始化一次。
+func Init() error {
+	once.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			initErr = errors.New("gin validator engine is not go-playground/validator")
+			return
+		}
+		v.RegisterTagNameFunc(fieldName)
+
+		uni := ut.New(en.New(), en.New(), zh.New())
+		enTrans, _ := uni.GetTranslator("en")
+		zhTrans, _ := uni.GetTranslator("zh")
+		if initErr = enTranslations.RegisterDefaultTranslations(v, enTrans); initErr != nil {
+			return
+		}
+		if initErr = zhTranslations.RegisterDefaultTranslations(v, zhTrans); initErr != nil {
+			return
+		}
+		translators[i18n.En] = enTrans
+		translators[i18n.ZhCN] = zhTrans
+	})
+	return initErr
+}
+
+// Translate 将绑定错误转换为字段错误列表，language 按 i18n.Fallbacks 回退
+func Translate(err error, language string) []FieldError {
+	if err := Init(); err != nil {
+		return []FieldError{{Rule: "invalid", Message: err.Error()}}
+	}
+	var trans ut.Translator
+	for _, l := range i18n.Fallbacks(language) {
+		if t, ok := translators[l]; ok {
+			trans = t
+			break
+		}
+	}
+	zhCN := trans == translators[i18n.ZhCN]
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, FieldError{
+				Field:   namespace(fe),
+				Rule:    fe.Tag(),
+				Message: fe.Translate(trans),
+			})
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		msg := fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type)
+		if zhCN {
+			msg = fmt.Sprintf("%s必须是%s类型", typeErr.Field, typeErr.Type)
+		}
+		return []FieldError{{Field: typeErr.Field, Rule: "type", Message: msg}}
+	}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		msg := fmt.Sprintf("%q is not a valid number", numErr.Num)
+		if zhCN {
+			msg = fmt.Sprintf("%q 不是有效的数字", numErr.Num)
+		}
+		return []FieldError{{Rule: "type", Message: msg}}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || strings.Contains(err.Error(), "EOF") {
+		msg := "request body is not valid JSON"
+		if zhCN {
+			msg = "请求体不是合法的 JSON"
+		}
+		return []FieldError{{Rule: "json", Message: msg}}
+	}
+	return []FieldError{{Rule: "invalid", Message: err.Error()}}
+}
+
+// namespace 返回去掉顶层结构体名的字段路径，如 items[0].name
+func namespace(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,20 @@
+// Package version holds build information injected at link time, e.g.
+//
+//	go build -ldflags "-X civ/internal/pkg/version.Version=v1.2.0 -X civ/internal/pkg/version.Commit=$(git rev-parse --short HEAD)"
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// String 返回单行的版本信息
+func String() string {
+	return fmt.Sprintf("civ %s (commit %s, built %s, %s)", Version, Commit, BuildDate, runtime.Version())
+}
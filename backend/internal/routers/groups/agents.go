@@ -0,0 +1,20 @@
+package groups
+
+import (
+	"civ/internal/agent"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgentsRouters registers the GET /agents route listing live agents and their capabilities.
+func AgentsRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.GET("/agents", openapi.Doc{
+		Summary:     "List registered agents",
+		Description: "Agents that registered over gRPC and are still sending heartbeats, with their models, context limits, analysis types and version.",
+		Tags:        []string{"agents"},
+		Response:    []agent.AgentInfo{},
+	}, controller.AgentsController.List)
+}
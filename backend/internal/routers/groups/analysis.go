@@ -0,0 +1,49 @@
+package groups
+
+import (
+	"civ/internal/agent"
+	"civ/internal/controller/analysis"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalysisRouters registers the routes for starting, tracking and canceling analyses.
+func AnalysisRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.POST("/jobs/:id/analyses", openapi.Doc{
+		Summary:     "Start an analysis of a job",
+		Description: "Creates a pending analysis and runs it in the background on this server; track or cancel it by the returned id. Usage is attributed to the bearer token's user.",
+		Tags:        []string{"analyses"},
+		Path:        analysis.JobPath{},
+		Body:        analysis.StartBody{},
+		Response:    model.Analysis{},
+		Errors:      []int{errors.InvalidParameter, errors.NotLogin, errors.JobDoesNotExist, errors.DailyBudgetExceeded, errors.MonthlyBudgetExceeded},
+	}, controller.AnalysisController.Start)
+	r.GET("/analyses/:id", openapi.Doc{
+		Summary:     "Get an analysis",
+		Description: "Status and, once succeeded, the result of an analysis; failed and canceled analyses carry the error.",
+		Tags:        []string{"analyses"},
+		Path:        analysis.IDPath{},
+		Response:    model.Analysis{},
+		Errors:      []int{errors.InvalidParameter, errors.AnalysisDoesNotExist},
+	}, controller.AnalysisController.Get)
+	r.GET("/analyses/:id/progress", openapi.Doc{
+		Summary:     "Get log upload progress of a running analysis",
+		Description: "Bytes of the job log streamed to the agent so far and how many ranges the agent requested.",
+		Tags:        []string{"analyses"},
+		Path:        analysis.IDPath{},
+		Response:    agent.StreamProgress{},
+		Errors:      []int{errors.InvalidParameter, errors.AnalysisNotRunning},
+	}, controller.AnalysisController.Progress)
+	r.POST("/analyses/:id/cancel", openapi.Doc{
+		Summary:     "Cancel a running analysis",
+		Description: "Cancels the stream to the agent; the analysis is stored with status canceled.",
+		Tags:        []string{"analyses"},
+		Path:        analysis.IDPath{},
+		Errors:      []int{errors.InvalidParameter, errors.AnalysisNotRunning},
+	}, controller.AnalysisController.Cancel)
+}
@@ -0,0 +1,20 @@
+package groups
+
+import (
+	"civ/internal/controller/errorcode"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCodeRouters registers the GET /error-codes route listing every registered business code.
+func ErrorCodeRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.GET("/error-codes", openapi.Doc{
+		Summary:     "List business error codes",
+		Description: "Every registered code with its owning module and message templates per locale.",
+		Tags:        []string{"meta"},
+		Response:    errorcode.CodesResponse{},
+	}, controller.ErrorCodeController.List)
+}
@@ -0,0 +1,50 @@
+package groups
+
+import (
+	"civ/internal/controller/analysis"
+	"civ/internal/controller/feedback"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedbackRouters registers the routes for rating analyses, accuracy reports and exporting labelled examples.
+func FeedbackRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.PUT("/analyses/:id/feedback", openapi.Doc{
+		Summary:     "Rate an analysis",
+		Description: "Rates a succeeded analysis as correct, partially or wrong with the actual root cause and fix commit. Rating again replaces the previous feedback. The rater is the bearer token's user.",
+		Tags:        []string{"feedback"},
+		Path:        analysis.IDPath{},
+		Body:        feedback.RateBody{},
+		Response:    model.AnalysisFeedback{},
+		Errors:      []int{errors.InvalidParameter, errors.NotLogin, errors.AnalysisDoesNotExist, errors.AnalysisNoResult},
+	}, controller.FeedbackController.Rate)
+	r.GET("/analyses/:id/feedback", openapi.Doc{
+		Summary:  "Get the feedback of an analysis",
+		Tags:     []string{"feedback"},
+		Path:     analysis.IDPath{},
+		Response: model.AnalysisFeedback{},
+		Errors:   []int{errors.InvalidParameter, errors.NotFound},
+	}, controller.FeedbackController.Get)
+	r.GET("/feedback/accuracy", openapi.Doc{
+		Summary:     "Report analysis accuracy",
+		Description: "Counts ratings per model, prompt version or failure category. accuracy is the share of correct ratings; score counts partially as half correct.",
+		Tags:        []string{"feedback"},
+		Query:       feedback.AccuracyQuery{},
+		Response:    []service.AccuracyGroup{},
+		Errors:      []int{errors.InvalidParameter},
+	}, controller.FeedbackController.Accuracy)
+	r.GET("/feedback/export", openapi.Doc{
+		Summary:     "Export labelled examples as JSONL",
+		Description: "Streams one JSON object per rated analysis (application/x-ndjson) with the job, the analysis and the human feedback, for evaluating prompt changes offline. The response is not wrapped in the standard envelope.",
+		Tags:        []string{"feedback"},
+		Query:       feedback.FilterQuery{},
+		Response:    service.LabeledExample{},
+		Errors:      []int{errors.InvalidParameter},
+	}, controller.FeedbackController.Export)
+}
@@ -0,0 +1,27 @@
+package groups
+
+import (
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/health"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthRouters registers the liveness (/healthz) and readiness (/readyz) probes.
+func HealthRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.GET("/healthz", openapi.Doc{
+		Summary:  "Liveness probe",
+		Tags:     []string{"health"},
+		Response: map[string]string{},
+	}, controller.HealthController.Healthz)
+	r.GET("/readyz", openapi.Doc{
+		Summary:     "Readiness probe",
+		Description: "Returns HTTP 503 with per-check detail in data when a dependency is down or the server is shutting down.",
+		Tags:        []string{"health"},
+		Response:    health.Report{},
+		Errors:      []int{errors.ServerError},
+	}, controller.HealthController.Readyz)
+}
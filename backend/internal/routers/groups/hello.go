@@ -1,6 +1,7 @@
 package groups
 
 import (
+	"civ/internal/pkg/openapi"
 	"civ/internal/routers/setup"
 
 	"github.com/gin-gonic/gin"
@@ -8,5 +9,10 @@ import (
 
 // HelloRouters registers the GET /hello route on the given router group and binds it to controller.HelloController.HelloGin.
 func HelloRouters(router *gin.RouterGroup, controller setup.Controllers) {
-	router.GET("/hello", controller.HelloController.HelloGin)
+	r := openapi.Wrap(router)
+	r.GET("/hello", openapi.Doc{
+		Summary:  "Connectivity check",
+		Tags:     []string{"hello"},
+		Response: "",
+	}, controller.HelloController.HelloGin)
 }
@@ -0,0 +1,24 @@
+package groups
+
+import (
+	"civ/internal/controller/job"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobRouters registers the job routes on the given router group.
+func JobRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.GET("/jobs", openapi.Doc{
+		Summary:     "List CI jobs",
+		Description: "Paginated; the envelope carries pagination with total, next_cursor and links.",
+		Tags:        []string{"jobs"},
+		Query:       job.ListQuery{},
+		Response:    []model.Job{},
+		Errors:      []int{errors.InvalidParameter},
+	}, controller.JobController.List)
+}
@@ -0,0 +1,80 @@
+package groups
+
+import (
+	"civ/internal/controller/prompt"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PromptRouters registers the prompt template routes: template CRUD, versions and the active version pointer.
+func PromptRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.GET("/prompts", openapi.Doc{
+		Summary:     "List prompt templates",
+		Description: "Global templates have an empty project; a template with the same name and a project overrides the global one for that project.",
+		Tags:        []string{"prompts"},
+		Query:       prompt.ListQuery{},
+		Response:    []model.PromptTemplate{},
+		Errors:      []int{errors.InvalidParameter},
+	}, controller.PromptController.List)
+	r.POST("/prompts", openapi.Doc{
+		Summary:     "Create a prompt template",
+		Description: "The body becomes version 1 and is made active. It is a Go text/template and may only reference declared variables.",
+		Tags:        []string{"prompts"},
+		Body:        prompt.CreateBody{},
+		Response:    service.PromptDetail{},
+		Errors:      []int{errors.InvalidParameter, errors.PromptAlreadyExists, errors.PromptInvalid},
+	}, controller.PromptController.Create)
+	r.GET("/prompts/:id", openapi.Doc{
+		Summary:  "Get a prompt template with its active version",
+		Tags:     []string{"prompts"},
+		Path:     prompt.IDPath{},
+		Response: service.PromptDetail{},
+		Errors:   []int{errors.InvalidParameter, errors.PromptDoesNotExist},
+	}, controller.PromptController.Get)
+	r.PUT("/prompts/:id", openapi.Doc{
+		Summary:     "Update a prompt template",
+		Description: "Only the description can be changed; add a version to change the body.",
+		Tags:        []string{"prompts"},
+		Path:        prompt.IDPath{},
+		Body:        prompt.UpdateBody{},
+		Response:    model.PromptTemplate{},
+		Errors:      []int{errors.InvalidParameter, errors.PromptDoesNotExist},
+	}, controller.PromptController.Update)
+	r.DELETE("/prompts/:id", openapi.Doc{
+		Summary: "Delete a prompt template and all its versions",
+		Tags:    []string{"prompts"},
+		Path:    prompt.IDPath{},
+		Errors:  []int{errors.InvalidParameter, errors.PromptDoesNotExist},
+	}, controller.PromptController.Delete)
+	r.GET("/prompts/:id/versions", openapi.Doc{
+		Summary:  "List versions of a prompt template",
+		Tags:     []string{"prompts"},
+		Path:     prompt.IDPath{},
+		Response: []model.PromptVersion{},
+		Errors:   []int{errors.InvalidParameter, errors.PromptDoesNotExist},
+	}, controller.PromptController.ListVersions)
+	r.POST("/prompts/:id/versions", openapi.Doc{
+		Summary:     "Add a version to a prompt template",
+		Description: "Versions are immutable and numbered sequentially; set activate to use it for new analyses right away.",
+		Tags:        []string{"prompts"},
+		Path:        prompt.IDPath{},
+		Body:        prompt.AddVersionBody{},
+		Response:    model.PromptVersion{},
+		Errors:      []int{errors.InvalidParameter, errors.PromptDoesNotExist, errors.PromptInvalid},
+	}, controller.PromptController.AddVersion)
+	r.PUT("/prompts/:id/active", openapi.Doc{
+		Summary:     "Set the active version of a prompt template",
+		Description: "New analyses use the active version; pointing it at an older version rolls back.",
+		Tags:        []string{"prompts"},
+		Path:        prompt.IDPath{},
+		Body:        prompt.ActivateBody{},
+		Response:    service.PromptDetail{},
+		Errors:      []int{errors.InvalidParameter, errors.PromptDoesNotExist, errors.PromptVersionDoesNotExist},
+	}, controller.PromptController.Activate)
+}
@@ -0,0 +1,32 @@
+package groups
+
+import (
+	"civ/internal/controller/usage"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/openapi"
+	"civ/internal/routers/setup"
+	"civ/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsageRouters registers the routes reporting LLM token usage, cost and budgets.
+func UsageRouters(router *gin.RouterGroup, controller setup.Controllers) {
+	r := openapi.Wrap(router)
+	r.GET("/usage", openapi.Doc{
+		Summary:     "Report LLM usage and cost",
+		Description: "Daily or monthly totals of analyses, tokens and cost per project or user. Cost is priced with usage.prices at the time each analysis finished. Per-user totals count the user whose API token started the analysis; analyses without a requester are grouped under a null user_id.",
+		Tags:        []string{"usage"},
+		Query:       usage.ReportQuery{},
+		Response:    []service.UsageTotal{},
+		Errors:      []int{errors.InvalidParameter},
+	}, controller.UsageController.Report)
+	r.GET("/usage/budget", openapi.Doc{
+		Summary:     "Get the budget of a project",
+		Description: "Daily and monthly limits from usage.budgets and the cost spent so far; new analyses are rejected once a limit is reached.",
+		Tags:        []string{"usage"},
+		Query:       usage.BudgetQuery{},
+		Response:    service.BudgetStatus{},
+		Errors:      []int{errors.InvalidParameter},
+	}, controller.UsageController.Budget)
+}
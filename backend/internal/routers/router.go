@@ -1,18 +1,72 @@
 package routers
 
 import (
+	"civ/config"
+	"civ/internal/middleware"
+	"civ/internal/pkg/metrics"
+	"civ/internal/pkg/openapi"
+	"civ/internal/pkg/version"
 	"civ/internal/routers/groups"
 	"civ/internal/routers/setup"
+	"civ/internal/service"
+	"context"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupRouter registers API routes on the provided gin.Engine.
-// It creates controller instances via setup.NewControllers(), mounts the
-// "/api" route group on the given router, and registers application routes
-// (currently groups.HelloRouters) onto that group.
+// It creates controller instances via setup.NewControllers(), registers the
+// health probes at the root, mounts the "/api" route group on the given router
+// (API tokens sent as "Authorization: Bearer" are authenticated on that group),
+// and registers application routes (groups.HelloRouters, groups.JobRouters,
+// groups.ErrorCodeRouters, groups.AgentsRouters, groups.AnalysisRouters,
+// groups.PromptRouters, groups.UsageRouters, groups.FeedbackRouters) onto that
+// group.
+// The OpenAPI document generated from those registrations is served at
+// /api/openapi.json with Swagger UI at /api/docs/. When metrics are enabled the
+// Prometheus endpoint is mounted at the configured path outside of "/api".
 func SetupRouter(router *gin.Engine) {
+	cfg := config.GetConfig()
+	if cfg.Metrics.Enabled {
+		router.GET(metricsPath(cfg.Metrics.Path), gin.WrapH(metrics.Handler()))
+	}
+
 	Controllers := setup.NewControllers()
-	api := router.Group("/api")
+	groups.HealthRouters(router.Group("/"), *Controllers)
+
+	api := router.Group("/api", middleware.Authenticate(authenticate))
 	groups.HelloRouters(api, *Controllers)
+	groups.JobRouters(api, *Controllers)
+	groups.ErrorCodeRouters(api, *Controllers)
+	groups.AgentsRouters(api, *Controllers)
+	groups.AnalysisRouters(api, *Controllers)
+	groups.PromptRouters(api, *Controllers)
+	groups.UsageRouters(api, *Controllers)
+	groups.FeedbackRouters(api, *Controllers)
+
+	api.GET("/openapi.json", openapi.Handler(openapi.Info{
+		Title:       "CI-Vision API",
+		Description: apiDescription,
+		Version:     version.Version,
+	}))
+	api.GET("/docs/*any", openapi.UIHandler("/api/openapi.json"))
+}
+
+const apiDescription = "Every response is wrapped in the Result envelope; see the Result schema for business codes. " +
+	"Messages are localized by the lang query parameter or Accept-Language (zh_CN, en)."
+
+// authenticate 返回 API 令牌所属用户的 ID
+func authenticate(ctx context.Context, token string) (uint, error) {
+	t, err := service.NewTokenService().Authenticate(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	return t.UserID, nil
+}
+
+func metricsPath(path string) string {
+	if path == "" {
+		return "/metrics"
+	}
+	return path
 }
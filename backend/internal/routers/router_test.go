@@ -0,0 +1,218 @@
+package routers
+
+import (
+	"civ/config/autoload"
+	"civ/data/datatest"
+	"civ/internal/agent"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpctest"
+	"civ/internal/service"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// stallingAnalyzer 请求一次完整日志后一直等待，直到流被取消
+type stallingAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+}
+
+func (stallingAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	start, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_LogRequest{
+		LogRequest: &analyzer.LogRequest{Length: start.GetStart().GetLogSize()},
+	}}); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func useTestAgent(t *testing.T, srv analyzer.AnalyzerServer) {
+	t.Helper()
+	addr := grpctest.Serve(t, func(s *grpc.Server) {
+		analyzer.RegisterAnalyzerServer(s, srv)
+	})
+	client, err := agent.NewClient(autoload.AgentConfig{Address: addr, Timeout: time.Minute})
+	require.NoError(t, err)
+	previous := agent.DefaultClient
+	agent.DefaultClient = client
+	t.Cleanup(func() {
+		agent.DefaultClient = previous
+		client.Close()
+	})
+}
+
+type result[T any] struct {
+	Code int `json:"code"`
+	Data T   `json:"data"`
+}
+
+func do[T any](t *testing.T, r http.Handler, method, path, token, body string) (int, result[T]) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var res result[T]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res), w.Body.String())
+	return w.Code, res
+}
+
+func TestStartAnalysisOverHTTP(t *testing.T) {
+	db := datatest.Use(t)
+	useTestAgent(t, stallingAnalyzer{})
+	ctx := context.Background()
+
+	logPath := filepath.Join(t.TempDir(), "build.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("error: undefined: foo\n"), 0o644))
+	job := &model.Job{Project: "civ", ExternalID: "1", Name: "build", Status: model.JobFailed, LogPath: logPath}
+	require.NoError(t, service.NewJobService().Upsert(ctx, job))
+	user, err := service.NewUserService().Create(ctx, "alice", "", "pa55word", "")
+	require.NoError(t, err)
+	token, _, err := service.NewTokenService().Issue(ctx, "alice", "ci", 0)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRouter(r)
+
+	status, _ := do[any](t, r, http.MethodPost, fmt.Sprintf("/api/jobs/%d/analyses", job.ID), "civ_invalid", "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, started := do[model.Analysis](t, r, http.MethodPost, fmt.Sprintf("/api/jobs/%d/analyses", job.ID), token, `{"model":"deepseek"}`)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, errors.SUCCESS, started.Code)
+	assert.Equal(t, model.AnalysisPending, started.Data.Status)
+	assert.Equal(t, "deepseek", started.Data.Model)
+	assert.Equal(t, &user.ID, started.Data.RequestedBy)
+
+	progressPath := fmt.Sprintf("/api/analyses/%d/progress", started.Data.ID)
+	require.Eventually(t, func() bool {
+		_, progress := do[agent.StreamProgress](t, r, http.MethodGet, progressPath, "", "")
+		return progress.Code == errors.SUCCESS && progress.Data.Requests == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	_, progress := do[agent.StreamProgress](t, r, http.MethodGet, progressPath, "", "")
+	assert.Equal(t, agent.StreamProgress{LogSize: 22, BytesSent: 22, Requests: 1}, progress.Data)
+
+	status, _ = do[any](t, r, http.MethodPost, fmt.Sprintf("/api/analyses/%d/cancel", started.Data.ID), "", "")
+	require.Equal(t, http.StatusOK, status)
+	require.Eventually(t, func() bool {
+		var stored model.Analysis
+		return db.First(&stored, started.Data.ID).Error == nil && stored.Status == model.AnalysisCanceled
+	}, 5*time.Second, 10*time.Millisecond)
+	_, progress = do[agent.StreamProgress](t, r, http.MethodGet, progressPath, "", "")
+	assert.Equal(t, errors.AnalysisNotRunning, progress.Code)
+
+	status, stored := do[model.Analysis](t, r, http.MethodGet, fmt.Sprintf("/api/analyses/%d", started.Data.ID), "", "")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, model.AnalysisCanceled, stored.Data.Status)
+	assert.NotEmpty(t, stored.Data.Error)
+}
+
+// summaryAnalyzer 不请求日志，直接返回固定的分析摘要
+type summaryAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+	summary string
+}
+
+func (a summaryAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	return stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_Result{
+		Result: &analyzer.AnalyzeReply{Summary: a.summary},
+	}})
+}
+
+func TestStartedAnalysisRoutesToRegisteredAgent(t *testing.T) {
+	datatest.Use(t)
+	useTestAgent(t, summaryAnalyzer{summary: "static"})
+	ctx := context.Background()
+
+	addr := grpctest.Serve(t, func(s *grpc.Server) {
+		analyzer.RegisterAnalyzerServer(s, summaryAnalyzer{summary: "registered"})
+	})
+	registry := agent.NewRegistry(autoload.AgentConfig{Timeout: time.Minute})
+	require.NoError(t, registry.Register(agent.AgentInfo{ID: "qwen-agent", Address: addr, Models: []agent.ModelInfo{{Name: "qwen"}}}))
+	previous := agent.DefaultRegistry
+	agent.DefaultRegistry = registry
+	t.Cleanup(func() {
+		agent.DefaultRegistry = previous
+		registry.Deregister("qwen-agent")
+	})
+
+	job := &model.Job{Project: "civ", ExternalID: "1", Name: "build", Status: model.JobFailed}
+	require.NoError(t, service.NewJobService().Upsert(ctx, job))
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRouter(r)
+
+	status, started := do[model.Analysis](t, r, http.MethodPost, fmt.Sprintf("/api/jobs/%d/analyses", job.ID), "", `{"model":"qwen"}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Nil(t, started.Data.RequestedBy, "anonymous requests are not attributed")
+
+	var stored result[model.Analysis]
+	require.Eventually(t, func() bool {
+		_, stored = do[model.Analysis](t, r, http.MethodGet, fmt.Sprintf("/api/analyses/%d", started.Data.ID), "", "")
+		return stored.Data.Status == model.AnalysisSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "registered", stored.Data.Summary)
+}
+
+func TestRateTakesRaterFromToken(t *testing.T) {
+	db := datatest.Use(t)
+	ctx := context.Background()
+
+	analysis := &model.Analysis{JobID: 1, Status: model.AnalysisSucceeded, Model: "deepseek"}
+	require.NoError(t, db.Create(analysis).Error)
+	alice, err := service.NewUserService().Create(ctx, "alice", "", "pa55word", "")
+	require.NoError(t, err)
+	_, err = service.NewUserService().Create(ctx, "bob", "", "pa55word", "")
+	require.NoError(t, err)
+	token, _, err := service.NewTokenService().Issue(ctx, "alice", "ci", 0)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRouter(r)
+	path := fmt.Sprintf("/api/analyses/%d/feedback", analysis.ID)
+
+	// 请求体中的 rated_by 不再生效，无法冒充其他用户
+	status, rated := do[model.AnalysisFeedback](t, r, http.MethodPut, path, token, `{"rating":"correct","rated_by":2}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, &alice.ID, rated.Data.RatedBy)
+
+	status, rated = do[model.AnalysisFeedback](t, r, http.MethodPut, path, "", `{"rating":"wrong","rated_by":2}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Nil(t, rated.Data.RatedBy)
+
+	status, _ = do[any](t, r, http.MethodPut, path, "civ_unknown", `{"rating":"wrong"}`)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
@@ -1,20 +1,51 @@
 package setup
 
-import "civ/internal/controller/hello"
+import (
+	"civ/internal/controller/agents"
+	"civ/internal/controller/analysis"
+	"civ/internal/controller/errorcode"
+	"civ/internal/controller/feedback"
+	"civ/internal/controller/health"
+	"civ/internal/controller/hello"
+	"civ/internal/controller/job"
+	"civ/internal/controller/prompt"
+	"civ/internal/controller/usage"
+)
 
 type Controllers struct {
-	HelloController hello.HelloController
+	HelloController     hello.HelloController
+	HealthController    health.HealthController
+	JobController       job.JobController
+	ErrorCodeController errorcode.ErrorCodeController
+	AgentsController    agents.AgentsController
+	AnalysisController  analysis.AnalysisController
+	PromptController    prompt.PromptController
+	UsageController     usage.UsageController
+	FeedbackController  feedback.FeedbackController
 }
 
-// NewControllers creates and returns a Controllers instance with its HelloController
-// field initialized.
-//
-// It instantiates a hello.HelloController via hello.NewHelloController and returns
-// a pointer to the populated Controllers struct.
+// NewControllers creates and returns a Controllers instance with every
+// controller field initialized via its constructor.
 func NewControllers() *Controllers {
 
 	HelloController := hello.NewHelloController()
+	HealthController := health.NewHealthController()
+	JobController := job.NewJobController()
+	ErrorCodeController := errorcode.NewErrorCodeController()
+	AgentsController := agents.NewAgentsController()
+	AnalysisController := analysis.NewAnalysisController()
+	PromptController := prompt.NewPromptController()
+	UsageController := usage.NewUsageController()
+	FeedbackController := feedback.NewFeedbackController()
 	return &Controllers{
-		HelloController: *HelloController,
+		HelloController:     *HelloController,
+		HealthController:    *HealthController,
+		JobController:       *JobController,
+		ErrorCodeController: *ErrorCodeController,
+		AgentsController:    *AgentsController,
+		AnalysisController:  *AnalysisController,
+		PromptController:    *PromptController,
+		UsageController:     *UsageController,
+		FeedbackController:  *FeedbackController,
 	}
 }
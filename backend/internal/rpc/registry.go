@@ -0,0 +1,47 @@
+package rpc
+
+import (
+	"civ/internal/agent"
+	"civ/internal/middleware"
+	pb "civ/proto/analyzer/v1"
+	"context"
+)
+
+// RegistryServer 实现 analyzer.Registry，代理启动后调用 Register 上报能力并定期重复作为心跳
+type RegistryServer struct {
+	pb.UnimplementedRegistryServer
+	registry *agent.Registry
+}
+
+// NewRegistryServer 创建注册服务
+func NewRegistryServer(registry *agent.Registry) *RegistryServer {
+	return &RegistryServer{registry: registry}
+}
+
+// Register 新增或刷新代理，返回下一次心跳的间隔
+func (s *RegistryServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterReply, error) {
+	if err := s.registry.Register(fromProto(req.GetAgent())); err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	return &pb.RegisterReply{HeartbeatIntervalSeconds: int32(s.registry.HeartbeatInterval().Seconds())}, nil
+}
+
+// Deregister 代理退出前主动注销
+func (s *RegistryServer) Deregister(_ context.Context, req *pb.DeregisterRequest) (*pb.DeregisterReply, error) {
+	s.registry.Deregister(req.GetId())
+	return &pb.DeregisterReply{}, nil
+}
+
+func fromProto(in *pb.AgentInfo) agent.AgentInfo {
+	info := agent.AgentInfo{
+		ID:            in.GetId(),
+		Address:       in.GetAddress(),
+		Version:       in.GetVersion(),
+		AnalysisTypes: in.GetAnalysisTypes(),
+		Tools:         in.GetTools(),
+	}
+	for _, m := range in.GetModels() {
+		info.Models = append(info.Models, agent.ModelInfo{Name: m.GetName(), MaxContextTokens: m.GetMaxContextTokens()})
+	}
+	return info
+}
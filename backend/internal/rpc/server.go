@@ -0,0 +1,34 @@
+// Package rpc 提供后端自身的 gRPC 服务：代理注册和心跳，以及代理分析过程中回调的只读工具
+package rpc
+
+import (
+	"civ/config"
+	"civ/config/autoload"
+	"civ/internal/middleware"
+	"civ/internal/pkg/tlsconfig"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NewServer 按配置创建 gRPC 服务，启用 TLS 时设置了 CAFile 则要求客户端证书；
+// token 每次调用从当前配置读取，支持热加载；合约版本不兼容的调用被拒绝
+func NewServer(cfg autoload.GRPCConfig) (*grpc.Server, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS.Enabled {
+		tlsConf, err := tlsconfig.Server(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConf)
+	}
+	token := func() string { return config.GetConfig().GRPC.Token }
+	return grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(middleware.UnaryServerRecovery(), middleware.UnaryServerToken(token), UnaryServerAPIVersion()),
+		grpc.ChainStreamInterceptor(middleware.StreamServerRecovery(), middleware.StreamServerToken(token), StreamServerAPIVersion()),
+	), nil
+}
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"civ/internal/middleware"
+	"civ/internal/service"
+	pb "civ/proto/analyzer/v1"
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ToolTokenKey metadata 中工具令牌的键，值为 AnalyzeStart.tool_token
+const ToolTokenKey = "x-civ-tool-token"
+
+// ToolsServer 实现 analyzer.Tools，每次调用按工具令牌授权，只能访问被分析任务所在项目的数据
+type ToolsServer struct {
+	pb.UnimplementedToolsServer
+	tools service.ToolService
+}
+
+// NewToolsServer 创建工具服务
+func NewToolsServer(tools service.ToolService) *ToolsServer {
+	return &ToolsServer{tools: tools}
+}
+
+func (s *ToolsServer) authorize(ctx context.Context) (*service.ToolScope, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ToolTokenKey); len(values) > 0 {
+			token = values[0]
+		}
+	}
+	scope, err := s.tools.Authorize(ctx, token)
+	if err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	return scope, nil
+}
+
+func (s *ToolsServer) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	scope, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job, err := s.tools.GetJob(ctx, scope, uint(req.GetJobId()))
+	if err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	return service.JobMessage(job), nil
+}
+
+func (s *ToolsServer) ListRecentRuns(ctx context.Context, req *pb.ListRecentRunsRequest) (*pb.ListRecentRunsReply, error) {
+	scope, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.tools.ListRecentRuns(ctx, scope, req.GetName(), req.GetBranch(), int(req.GetLimit()))
+	if err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	reply := &pb.ListRecentRunsReply{Runs: make([]*pb.Job, 0, len(jobs))}
+	for i := range jobs {
+		reply.Runs = append(reply.Runs, service.JobMessage(&jobs[i]))
+	}
+	return reply, nil
+}
+
+func (s *ToolsServer) GetLogRange(ctx context.Context, req *pb.GetLogRangeRequest) (*pb.GetLogRangeReply, error) {
+	scope, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, size, err := s.tools.GetLogRange(ctx, scope, uint(req.GetJobId()), req.GetOffset(), req.GetLength())
+	if err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	return &pb.GetLogRangeReply{Offset: min(req.GetOffset(), size), Data: data, LogSize: size}, nil
+}
+
+func (s *ToolsServer) GetCommitDiff(ctx context.Context, req *pb.GetCommitDiffRequest) (*pb.GetCommitDiffReply, error) {
+	scope, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := s.tools.GetCommitDiff(ctx, scope, req.GetCommitSha(), int(req.GetMaxBytes()))
+	if err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	return &pb.GetCommitDiffReply{CommitSha: diff.SHA, Diff: diff.Diff, Truncated: diff.Truncated}, nil
+}
+
+func (s *ToolsServer) SearchSimilarFailures(ctx context.Context, req *pb.SearchSimilarFailuresRequest) (*pb.SearchSimilarFailuresReply, error) {
+	scope, err := s.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := s.tools.SearchSimilarFailures(ctx, scope, req.GetQuery(), req.GetCategory(), int(req.GetLimit()))
+	if err != nil {
+		return nil, middleware.GRPCError(ctx, err)
+	}
+	reply := &pb.SearchSimilarFailuresReply{Failures: make([]*pb.SimilarFailure, 0, len(failures))}
+	for i := range failures {
+		f := &failures[i]
+		reply.Failures = append(reply.Failures, &pb.SimilarFailure{
+			Job:        service.JobMessage(&f.Job),
+			AnalysisId: int64(f.Analysis.ID),
+			Summary:    f.Analysis.Summary,
+			RootCause:  f.Analysis.RootCause,
+			Suggestion: f.Analysis.Suggestion,
+			Category:   f.Analysis.Category,
+		})
+	}
+	return reply, nil
+}
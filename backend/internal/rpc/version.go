@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"civ/internal/middleware"
+	"civ/internal/pkg/errors"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// checkAPIVersion 校验调用方 metadata 中的合约版本，并在 header（失败时在 trailer）中返回本服务的版本。
+// 未携带版本的调用方是版本化之前的代理，同样按不兼容处理
+func checkAPIVersion(ctx context.Context) error {
+	own := metadata.Pairs(analyzer.APIVersionKey, analyzer.APIVersion)
+	var got string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(analyzer.APIVersionKey); len(values) > 0 {
+			got = values[0]
+		}
+	}
+	if got == analyzer.APIVersion {
+		return grpc.SetHeader(ctx, own)
+	}
+	_ = grpc.SetTrailer(ctx, own)
+	if got == "" {
+		got = "unknown"
+	}
+	err := errors.NewBusinessError(errors.AgentVersionMismatch).
+		WithParam("agent_version", got).
+		WithParam("api_version", analyzer.APIVersion)
+	return middleware.GRPCError(ctx, err)
+}
+
+// UnaryServerAPIVersion 拒绝合约版本不兼容的调用，返回 FailedPrecondition
+func UnaryServerAPIVersion() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkAPIVersion(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerAPIVersion 与 UnaryServerAPIVersion 相同，用于流式调用
+func StreamServerAPIVersion() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkAPIVersion(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
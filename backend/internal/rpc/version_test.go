@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"civ/config/autoload"
+	"civ/internal/agent"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpcerr"
+	"civ/internal/pkg/grpctest"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistryRejectsIncompatibleAPIVersion(t *testing.T) {
+	addr := grpctest.Serve(t, func(s *grpc.Server) {
+		analyzer.RegisterRegistryServer(s, NewRegistryServer(agent.NewRegistry(autoload.AgentConfig{})))
+	}, grpc.UnaryInterceptor(UnaryServerAPIVersion()))
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := analyzer.NewRegistryClient(conn)
+
+	var trailer metadata.MD
+	_, err = client.Deregister(context.Background(), &analyzer.DeregisterRequest{Id: "a"}, grpc.Trailer(&trailer))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, []string{analyzer.APIVersion}, trailer.Get(analyzer.APIVersionKey))
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, grpcerr.FromError(err), &businessError)
+	assert.Equal(t, errors.AgentVersionMismatch, businessError.GetCode())
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), analyzer.APIVersionKey, analyzer.APIVersion)
+	_, err = client.Deregister(ctx, &analyzer.DeregisterRequest{Id: "a"}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{analyzer.APIVersion}, header.Get(analyzer.APIVersionKey))
+}
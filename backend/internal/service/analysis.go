@@ -0,0 +1,274 @@
+package service
+
+import (
+	"civ/config"
+	"civ/data"
+	"civ/internal/agent"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/metrics"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	stderrors "errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AnalysisService interface {
+	// Analyze 为任务创建一条分析记录并同步调用支持 modelName 的代理完成分析，
+	// modelName 为空时使用 agent.model。日志按代理的请求流式发送，不受单条消息大小限制。
+	// 任务所在项目的费用达到 usage.budgets 上限时不创建分析。requestedBy 为发起分析的用户，用量按其统计
+	Analyze(ctx context.Context, jobID uint, modelName string, requestedBy *uint) (*model.Analysis, error)
+	// Start 与 Analyze 相同，但创建分析记录后立即返回 pending 状态的记录，分析在本实例后台进行，
+	// 不随 ctx 取消，可通过 Progress 查看进度、Cancel 取消，结果写入分析记录
+	Start(ctx context.Context, jobID uint, modelName string, requestedBy *uint) (*model.Analysis, error)
+	// Get 返回分析记录，不存在时返回 AnalysisDoesNotExist
+	Get(ctx context.Context, id uint) (*model.Analysis, error)
+	// Progress 返回进行中的分析已发送的日志进度，分析不在本实例上进行时返回 AnalysisNotRunning
+	Progress(ctx context.Context, id uint) (*agent.StreamProgress, error)
+	// Cancel 取消进行中的分析，分析记录标记为 canceled
+	Cancel(ctx context.Context, id uint) error
+}
+
+type analysisServiceImpl struct {
+	db *gorm.DB
+}
+
+func NewAnalysisService() AnalysisService {
+	return &analysisServiceImpl{db: data.DB}
+}
+
+func (s *analysisServiceImpl) Analyze(ctx context.Context, jobID uint, modelName string, requestedBy *uint) (*model.Analysis, error) {
+	job, analysis, err := s.create(ctx, jobID, modelName, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	runCtx, done := runningAnalyses.start(ctx, analysis.ID)
+	return analysis, s.run(runCtx, done, job, analysis)
+}
+
+func (s *analysisServiceImpl) Start(ctx context.Context, jobID uint, modelName string, requestedBy *uint) (*model.Analysis, error) {
+	job, analysis, err := s.create(ctx, jobID, modelName, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	// 返回前登记，调用方拿到记录后即可查询进度或取消
+	runCtx, done := runningAnalyses.start(context.WithoutCancel(ctx), analysis.ID)
+	// 后台分析会修改 analysis，返回副本
+	created := *analysis
+	go func() {
+		if err := s.run(runCtx, done, job, analysis); err != nil {
+			slog.WarnContext(runCtx, "background analysis failed", "analysis_id", analysis.ID, "error", err)
+		}
+	}()
+	return &created, nil
+}
+
+// create 校验任务和预算后创建 pending 状态的分析记录
+func (s *analysisServiceImpl) create(ctx context.Context, jobID uint, modelName string, requestedBy *uint) (*model.Job, *model.Analysis, error) {
+	job, err := NewJobService().Get(ctx, jobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := NewUsageService().CheckBudget(ctx, job.Project); err != nil {
+		return nil, nil, err
+	}
+	if modelName == "" {
+		modelName = config.GetConfig().Agent.Model
+	}
+	analysis := &model.Analysis{
+		JobID:       job.ID,
+		Status:      model.AnalysisPending,
+		Model:       modelName,
+		RequestedBy: requestedBy,
+	}
+	if err := s.db.WithContext(ctx).Create(analysis).Error; err != nil {
+		return nil, nil, err
+	}
+	return job, analysis, nil
+}
+
+// run 执行已通过 runningAnalyses.start 登记的分析，ctx 和 done 为登记时返回的值
+func (s *analysisServiceImpl) run(ctx context.Context, done func() bool, job *model.Job, analysis *model.Analysis) error {
+	started := time.Now()
+	analysis.Status = model.AnalysisRunning
+	analysis.StartedAt = &started
+	// 登记后即可被取消，状态仍需保存，取消在调用代理时生效
+	if err := s.db.WithContext(context.WithoutCancel(ctx)).Save(analysis).Error; err != nil {
+		done()
+		return err
+	}
+
+	reply, usage, err := s.call(ctx, job, analysis)
+	canceled := done()
+	// 分析被取消或请求方断开后仍需保存结果
+	ctx = context.WithoutCancel(ctx)
+	finished := time.Now()
+	analysis.FinishedAt = &finished
+	if err != nil && canceled {
+		analysis.Status = model.AnalysisCanceled
+		analysis.Error = err.Error()
+		metrics.IncAnalyses("canceled")
+		err = errors.Wrap(errors.AnalysisCanceled, err).WithParam("id", analysis.ID)
+	} else if err != nil {
+		analysis.Status = model.AnalysisFailed
+		analysis.Error = err.Error()
+		metrics.IncAnalyses("failure")
+	} else {
+		analysis.Status = model.AnalysisSucceeded
+		analysis.Summary = reply.GetSummary()
+		analysis.RootCause = reply.GetRootCause()
+		analysis.Suggestion = reply.GetSuggestion()
+		analysis.Category = reply.GetCategory()
+		metrics.IncAnalyses("success")
+	}
+	// 失败或被取消的分析同样消耗了模型调用，按代理最后上报的用量记录，否则可绕过预算。
+	// 用量先于最终状态保存，状态可见时用量已计入；记录失败不影响分析结果
+	if _, usageErr := NewUsageService().Record(ctx, job, analysis, usage); usageErr != nil {
+		slog.WarnContext(ctx, "record analysis usage failed", "analysis_id", analysis.ID, "error", usageErr)
+	}
+	if saveErr := s.db.WithContext(ctx).Save(analysis).Error; saveErr != nil {
+		return saveErr
+	}
+	if err != nil {
+		// 代理返回的错误已翻译为具体的业务码，其余错误归为分析失败
+		var businessError *errors.BusinessError
+		if stderrors.As(err, &businessError) {
+			return err
+		}
+		return errors.Wrap(errors.AnalysisFailed, err)
+	}
+	return nil
+}
+
+// call 以流式调用代理分析，返回结果和本次分析的用量：成功时为结果中的用量，
+// 否则为代理最后一次上报的累计用量，没有上报时为 nil
+func (s *analysisServiceImpl) call(ctx context.Context, job *model.Job, analysis *model.Analysis) (*analyzer.AnalyzeReply, *analyzer.Usage, error) {
+	client, err := agent.Route(analysis.Model)
+	if err != nil {
+		return nil, nil, err
+	}
+	log, closeLog, err := openLog(resolveLogPath(job.LogPath))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeLog()
+	start := &analyzer.AnalyzeStart{
+		AnalysisId: int64(analysis.ID),
+		Job:        JobMessage(job),
+		Model:      analysis.Model,
+	}
+	prompt, err := NewPromptService().Resolve(ctx, config.GetConfig().Agent.Prompt, job.Project, promptValues(job, analysis, log.Size()))
+	if err != nil {
+		return nil, nil, err
+	}
+	if prompt != nil {
+		analysis.PromptName = prompt.Name
+		analysis.PromptVersion = prompt.Version
+		start.Prompt = &analyzer.Prompt{Name: prompt.Name, Version: int32(prompt.Version), Text: prompt.Text}
+	}
+	if config.GetConfig().GRPC.Enabled {
+		start.ToolToken = issueToolToken(ToolScope{AnalysisID: analysis.ID, JobID: job.ID, Project: job.Project}, time.Now())
+	}
+	var usage *analyzer.Usage
+	reply, err := client.AnalyzeStream(ctx, start, log, func(p agent.StreamProgress) {
+		usage = p.Usage
+		runningAnalyses.update(analysis.ID, p)
+	})
+	if reply.GetUsage() != nil {
+		usage = reply.GetUsage()
+	}
+	return reply, usage, err
+}
+
+func (s *analysisServiceImpl) Get(ctx context.Context, id uint) (*model.Analysis, error) {
+	var analysis model.Analysis
+	err := s.db.WithContext(ctx).First(&analysis, id).Error
+	if stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.NewBusinessError(errors.AnalysisDoesNotExist).WithParam("id", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &analysis, nil
+}
+
+func (s *analysisServiceImpl) Progress(_ context.Context, id uint) (*agent.StreamProgress, error) {
+	p, ok := runningAnalyses.progress(id)
+	if !ok {
+		return nil, errors.NewBusinessError(errors.AnalysisNotRunning).WithParam("id", id)
+	}
+	return &p, nil
+}
+
+func (s *analysisServiceImpl) Cancel(_ context.Context, id uint) error {
+	if !runningAnalyses.cancel(id) {
+		return errors.NewBusinessError(errors.AnalysisNotRunning).WithParam("id", id)
+	}
+	return nil
+}
+
+// promptValues 返回渲染提示词时可用的内置变量，模板需要声明后才能引用
+func promptValues(job *model.Job, analysis *model.Analysis, logSize int64) map[string]any {
+	return map[string]any{
+		"project":    job.Project,
+		"job_id":     int(job.ID),
+		"job_name":   job.Name,
+		"branch":     job.Branch,
+		"commit_sha": job.CommitSHA,
+		"status":     job.Status,
+		"model":      analysis.Model,
+		"log_size":   int(logSize),
+	}
+}
+
+// JobMessage 将任务转换为发送给代理的消息
+func JobMessage(job *model.Job) *analyzer.Job {
+	msg := &analyzer.Job{
+		Id:        int64(job.ID),
+		Project:   job.Project,
+		Name:      job.Name,
+		Branch:    job.Branch,
+		CommitSha: job.CommitSHA,
+		Status:    job.Status,
+	}
+	if job.StartedAt != nil {
+		msg.StartedAt = job.StartedAt.Unix()
+	}
+	if job.FinishedAt != nil {
+		msg.FinishedAt = job.FinishedAt.Unix()
+	}
+	return msg
+}
+
+// resolveLogPath 将相对日志路径解析到 storage.log_dir 下
+func resolveLogPath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(config.GetConfig().Storage.LogDir, path)
+}
+
+// openLog 打开任务日志供流式发送，任务没有日志时返回空的日志
+func openLog(path string) (*io.SectionReader, func() error, error) {
+	if path == "" {
+		return io.NewSectionReader(strings.NewReader(""), 0, 0), func() error { return nil }, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return io.NewSectionReader(f, 0, info.Size()), f.Close, nil
+}
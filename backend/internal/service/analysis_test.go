@@ -0,0 +1,183 @@
+package service
+
+import (
+	"civ/config/autoload"
+	"civ/internal/agent"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/grpctest"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stallingAnalyzer 请求一次完整日志后一直等待，直到流被取消
+type stallingAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+}
+
+func (stallingAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	start, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_LogRequest{
+		LogRequest: &analyzer.LogRequest{Length: start.GetStart().GetLogSize()},
+	}}); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func useTestAgent(t *testing.T, srv analyzer.AnalyzerServer) {
+	t.Helper()
+	addr := grpctest.Serve(t, func(s *grpc.Server) {
+		analyzer.RegisterAnalyzerServer(s, srv)
+	})
+
+	client, err := agent.NewClient(autoload.AgentConfig{Address: addr, Timeout: time.Minute})
+	require.NoError(t, err)
+	previous := agent.DefaultClient
+	agent.DefaultClient = client
+	t.Cleanup(func() {
+		agent.DefaultClient = previous
+		client.Close()
+	})
+}
+
+func TestCancelRunningAnalysis(t *testing.T) {
+	db := useTestDB(t)
+	useTestAgent(t, stallingAnalyzer{})
+	ctx := context.Background()
+
+	logPath := filepath.Join(t.TempDir(), "build.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("error: undefined: foo\n"), 0o644))
+	job := &model.Job{Project: "civ", ExternalID: "1", Name: "build", Status: model.JobFailed, LogPath: logPath}
+	require.NoError(t, NewJobService().Upsert(ctx, job))
+
+	analyses := NewAnalysisService()
+	_, err := analyses.Progress(ctx, 1)
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.AnalysisNotRunning, businessError.GetCode())
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := analyses.Analyze(ctx, job.ID, "deepseek", nil)
+		result <- err
+	}()
+	require.Eventually(t, func() bool {
+		p, err := analyses.Progress(ctx, 1)
+		return err == nil && p.Requests == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	p, _ := analyses.Progress(ctx, 1)
+	assert.Equal(t, agent.StreamProgress{LogSize: 22, BytesSent: 22, Requests: 1}, *p)
+
+	require.NoError(t, analyses.Cancel(ctx, 1))
+	err = <-result
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.AnalysisCanceled, businessError.GetCode())
+
+	var stored model.Analysis
+	require.NoError(t, db.First(&stored, 1).Error)
+	assert.Equal(t, model.AnalysisCanceled, stored.Status)
+	assert.NotNil(t, stored.FinishedAt)
+}
+
+// promptEchoAnalyzer 不请求日志，直接把收到的提示词作为分析摘要返回，并报告模型用量
+type promptEchoAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+}
+
+func (promptEchoAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	start, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	return stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_Result{
+		Result: &analyzer.AnalyzeReply{
+			Summary: start.GetStart().GetPrompt().GetText(),
+			Usage:   &analyzer.Usage{Model: "deepseek-chat", PromptTokens: 1200, CompletionTokens: 300, LatencyMs: 800},
+		},
+	}})
+}
+
+func TestAnalyzeSendsResolvedPromptAndRecordsUsage(t *testing.T) {
+	db := useTestDB(t)
+	useTestAgent(t, promptEchoAnalyzer{})
+	ctx := context.Background()
+
+	job := &model.Job{Project: "civ", ExternalID: "1", Name: "build", Status: model.JobFailed}
+	require.NoError(t, NewJobService().Upsert(ctx, job))
+	_, err := NewPromptService().Create(ctx, &model.PromptTemplate{Name: "build_failure"}, PromptInput{
+		Body: "{{.job_name}} failed on {{.model}}",
+		Variables: []model.PromptVariable{
+			{Name: "job_name", Type: model.PromptVarString, Required: true},
+			{Name: "model", Type: model.PromptVarString},
+		},
+	})
+	require.NoError(t, err)
+
+	analysis, err := NewAnalysisService().Analyze(ctx, job.ID, "deepseek", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "build failed on deepseek", analysis.Summary)
+	assert.Equal(t, "build_failure", analysis.PromptName)
+	assert.Equal(t, 1, analysis.PromptVersion)
+
+	var usage model.AnalysisUsage
+	require.NoError(t, db.Where("analysis_id = ?", analysis.ID).First(&usage).Error)
+	assert.Equal(t, "civ", usage.Project)
+	assert.Equal(t, "deepseek-chat", usage.Model)
+	assert.Equal(t, int64(1200), usage.PromptTokens)
+	assert.Equal(t, int64(300), usage.CompletionTokens)
+	assert.Equal(t, int64(800), usage.LatencyMs)
+}
+
+// failingAnalyzer 调用模型并上报用量后失败
+type failingAnalyzer struct {
+	analyzer.UnimplementedAnalyzerServer
+}
+
+func (failingAnalyzer) AnalyzeStream(stream analyzer.Analyzer_AnalyzeStreamServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if err := stream.Send(&analyzer.AnalyzeStreamReply{Payload: &analyzer.AnalyzeStreamReply_Usage{
+		Usage: &analyzer.Usage{Model: "deepseek-chat", PromptTokens: 900, CompletionTokens: 100, LatencyMs: 500},
+	}}); err != nil {
+		return err
+	}
+	return status.Error(codes.Internal, "model returned malformed output")
+}
+
+func TestFailedAnalysisRecordsReportedUsage(t *testing.T) {
+	db := useTestDB(t)
+	useTestAgent(t, failingAnalyzer{})
+	ctx := context.Background()
+
+	job := &model.Job{Project: "civ", ExternalID: "1", Name: "build", Status: model.JobFailed}
+	require.NoError(t, NewJobService().Upsert(ctx, job))
+
+	analysis, err := NewAnalysisService().Analyze(ctx, job.ID, "deepseek", nil)
+	require.Error(t, err)
+	assert.Equal(t, model.AnalysisFailed, analysis.Status)
+
+	var usage model.AnalysisUsage
+	require.NoError(t, db.Where("analysis_id = ?", analysis.ID).First(&usage).Error)
+	assert.Equal(t, "deepseek-chat", usage.Model)
+	assert.Equal(t, int64(900), usage.PromptTokens)
+	assert.Equal(t, int64(100), usage.CompletionTokens)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 准确率的分组维度
+const (
+	AccuracyByModel    = "model"
+	AccuracyByPrompt   = "prompt"
+	AccuracyByCategory = "category"
+)
+
+// FeedbackInput 对分析的评价，RootCause、FixCommit 为实际的根因和修复提交
+type FeedbackInput struct {
+	Rating    string
+	RootCause string
+	FixCommit string
+	Comment   string
+	RatedBy   *uint
+}
+
+// FeedbackFilter 准确率统计和样本导出的过滤条件，零值表示不过滤
+type FeedbackFilter struct {
+	Project       string
+	Model         string
+	PromptName    string
+	PromptVersion int
+	Category      string
+	Rating        string
+}
+
+// AccuracyGroup 一个分组内的评价统计。Accuracy 为 correct 的占比，Score 中 partially 计为半个正确
+type AccuracyGroup struct {
+	Model         string  `json:"model,omitempty"`
+	PromptName    string  `json:"prompt_name,omitempty"`
+	PromptVersion int     `json:"prompt_version,omitempty"`
+	Category      string  `json:"category,omitempty"`
+	Total         int64   `json:"total"`
+	Correct       int64   `json:"correct"`
+	Partially     int64   `json:"partially"`
+	Wrong         int64   `json:"wrong"`
+	Accuracy      float64 `json:"accuracy"`
+	Score         float64 `json:"score"`
+}
+
+// LabeledExample 导出的标注样本：任务、分析结论和人工评价
+type LabeledExample struct {
+	AnalysisID      uint      `json:"analysis_id"`
+	JobID           uint      `json:"job_id"`
+	Project         string    `json:"project"`
+	JobName         string    `json:"job_name"`
+	Branch          string    `json:"branch"`
+	CommitSHA       string    `json:"commit_sha"`
+	Model           string    `json:"model"`
+	PromptName      string    `json:"prompt_name"`
+	PromptVersion   int       `json:"prompt_version"`
+	Category        string    `json:"category"`
+	Summary         string    `json:"summary"`
+	RootCause       string    `json:"root_cause"`
+	Suggestion      string    `json:"suggestion"`
+	Rating          string    `json:"rating"`
+	ActualRootCause string    `json:"actual_root_cause"`
+	FixCommit       string    `json:"fix_commit"`
+	Comment         string    `json:"comment"`
+	RatedAt         time.Time `json:"rated_at"`
+}
+
+type FeedbackService interface {
+	// Rate 保存对成功分析的评价，已有评价时覆盖
+	Rate(ctx context.Context, analysisID uint, input FeedbackInput) (*model.AnalysisFeedback, error)
+	Get(ctx context.Context, analysisID uint) (*model.AnalysisFeedback, error)
+	// Accuracy 按模型、提示词版本或失败分类统计评价
+	Accuracy(ctx context.Context, groupBy string, filter FeedbackFilter) ([]AccuracyGroup, error)
+	// Export 以 JSON Lines 格式将标注样本写入 w，按分析 ID 升序
+	Export(ctx context.Context, filter FeedbackFilter, w io.Writer) error
+}
+
+type feedbackServiceImpl struct {
+	db *gorm.DB
+}
+
+func NewFeedbackService() FeedbackService {
+	return &feedbackServiceImpl{db: data.DB}
+}
+
+func (s *feedbackServiceImpl) Rate(ctx context.Context, analysisID uint, input FeedbackInput) (*model.AnalysisFeedback, error) {
+	db := s.db.WithContext(ctx)
+	var analysis model.Analysis
+	err := db.First(&analysis, analysisID).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.NewBusinessError(errors.AnalysisDoesNotExist).WithParam("id", analysisID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if analysis.Status != model.AnalysisSucceeded {
+		return nil, errors.NewBusinessError(errors.AnalysisNoResult).WithParam("id", analysisID)
+	}
+	feedback := &model.AnalysisFeedback{
+		AnalysisID: analysisID,
+		Rating:     input.Rating,
+		RootCause:  input.RootCause,
+		FixCommit:  input.FixCommit,
+		Comment:    input.Comment,
+		RatedBy:    input.RatedBy,
+	}
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "analysis_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rating", "root_cause", "fix_commit", "comment", "rated_by", "updated_at"}),
+	}).Create(feedback).Error
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, analysisID)
+}
+
+func (s *feedbackServiceImpl) Get(ctx context.Context, analysisID uint) (*model.AnalysisFeedback, error) {
+	var feedback model.AnalysisFeedback
+	err := s.db.WithContext(ctx).Where("analysis_id = ?", analysisID).First(&feedback).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.NewBusinessError(errors.NotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// labeled 关联评价、分析和任务并应用过滤条件
+func (s *feedbackServiceImpl) labeled(ctx context.Context, filter FeedbackFilter) *gorm.DB {
+	db := s.db.WithContext(ctx).Table("analysis_feedbacks AS f").
+		Joins("JOIN analyses AS a ON a.id = f.analysis_id").
+		Joins("JOIN jobs AS j ON j.id = a.job_id")
+	for column, value := range map[string]string{
+		"j.project":     filter.Project,
+		"a.model":       filter.Model,
+		"a.prompt_name": filter.PromptName,
+		"a.category":    filter.Category,
+		"f.rating":      filter.Rating,
+	} {
+		if value != "" {
+			db = db.Where(column+" = ?", value)
+		}
+	}
+	if filter.PromptVersion > 0 {
+		db = db.Where("a.prompt_version = ?", filter.PromptVersion)
+	}
+	return db
+}
+
+func (s *feedbackServiceImpl) Accuracy(ctx context.Context, groupBy string, filter FeedbackFilter) ([]AccuracyGroup, error) {
+	// 分组字段均来自分析记录，列名与 AccuracyGroup 的字段对应
+	var keys []string
+	switch groupBy {
+	case AccuracyByPrompt:
+		keys = []string{"prompt_name", "prompt_version"}
+	case AccuracyByCategory:
+		keys = []string{"category"}
+	default:
+		keys = []string{"model"}
+	}
+	columns := make([]string, 0, len(keys)+4)
+	for _, key := range keys {
+		columns = append(columns, "a."+key+" AS "+key)
+	}
+	columns = append(columns,
+		"COUNT(*) AS total",
+		"SUM(CASE WHEN f.rating = '"+model.FeedbackCorrect+"' THEN 1 ELSE 0 END) AS correct",
+		"SUM(CASE WHEN f.rating = '"+model.FeedbackPartially+"' THEN 1 ELSE 0 END) AS partially",
+		"SUM(CASE WHEN f.rating = '"+model.FeedbackWrong+"' THEN 1 ELSE 0 END) AS wrong",
+	)
+	group := "a." + strings.Join(keys, ", a.")
+	groups := []AccuracyGroup{}
+	if err := s.labeled(ctx, filter).Select(columns).Group(group).Order(group).Scan(&groups).Error; err != nil {
+		return nil, err
+	}
+	for i := range groups {
+		g := &groups[i]
+		g.Accuracy = float64(g.Correct) / float64(g.Total)
+		g.Score = (float64(g.Correct) + float64(g.Partially)/2) / float64(g.Total)
+	}
+	return groups, nil
+}
+
+func (s *feedbackServiceImpl) Export(ctx context.Context, filter FeedbackFilter, w io.Writer) error {
+	rows, err := s.labeled(ctx, filter).Select(
+		"a.id AS analysis_id", "j.id AS job_id", "j.project", "j.name AS job_name", "j.branch", "j.commit_sha",
+		"a.model", "a.prompt_name", "a.prompt_version", "a.category", "a.summary", "a.root_cause", "a.suggestion",
+		"f.rating", "f.root_cause AS actual_root_cause", "f.fix_commit", "f.comment", "f.updated_at AS rated_at",
+	).Order("a.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	enc := json.NewEncoder(w)
+	for rows.Next() {
+		var example LabeledExample
+		if err := s.db.ScanRows(rows, &example); err != nil {
+			return err
+		}
+		if err := enc.Encode(example); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedFeedbackData 创建 civ、other 两个项目的分析，返回按创建顺序排列的分析
+func seedFeedbackData(t *testing.T, db *gorm.DB) []model.Analysis {
+	t.Helper()
+	jobs := []model.Job{
+		{Project: "civ", ExternalID: "1", Name: "build", Branch: "main", CommitSHA: "abc1234", Status: model.JobFailed},
+		{Project: "other", ExternalID: "1", Name: "test", Status: model.JobFailed},
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	analyses := []model.Analysis{
+		{JobID: jobs[0].ID, Status: model.AnalysisSucceeded, Model: "deepseek", PromptName: "build_failure", PromptVersion: 1, Category: "compile", RootCause: "undefined: foo"},
+		{JobID: jobs[0].ID, Status: model.AnalysisSucceeded, Model: "deepseek", PromptName: "build_failure", PromptVersion: 2, Category: "compile"},
+		{JobID: jobs[0].ID, Status: model.AnalysisSucceeded, Model: "qwen", PromptName: "build_failure", PromptVersion: 2, Category: "test"},
+		{JobID: jobs[1].ID, Status: model.AnalysisSucceeded, Model: "qwen", Category: "infra"},
+		{JobID: jobs[1].ID, Status: model.AnalysisFailed, Model: "qwen"},
+	}
+	require.NoError(t, db.Create(&analyses).Error)
+	return analyses
+}
+
+func TestRateAnalysis(t *testing.T) {
+	db := useTestDB(t)
+	analyses := seedFeedbackData(t, db)
+	feedback := NewFeedbackService()
+	ctx := context.Background()
+	alice := uint(1)
+
+	got, err := feedback.Rate(ctx, analyses[0].ID, FeedbackInput{Rating: model.FeedbackWrong, RootCause: "flaky network", RatedBy: &alice})
+	require.NoError(t, err)
+	assert.Equal(t, model.FeedbackWrong, got.Rating)
+
+	got, err = feedback.Rate(ctx, analyses[0].ID, FeedbackInput{Rating: model.FeedbackCorrect, FixCommit: "def5678"})
+	require.NoError(t, err)
+	assert.Equal(t, model.FeedbackCorrect, got.Rating, "rating again replaces the feedback")
+	assert.Equal(t, "def5678", got.FixCommit)
+	assert.Empty(t, got.RootCause)
+	assert.Nil(t, got.RatedBy)
+	var count int64
+	require.NoError(t, db.Model(&model.AnalysisFeedback{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	_, err = feedback.Rate(ctx, analyses[4].ID, FeedbackInput{Rating: model.FeedbackWrong})
+	requireCode(t, err, errors.AnalysisNoResult)
+	_, err = feedback.Rate(ctx, 99, FeedbackInput{Rating: model.FeedbackWrong})
+	requireCode(t, err, errors.AnalysisDoesNotExist)
+	_, err = feedback.Get(ctx, analyses[1].ID)
+	requireCode(t, err, errors.NotFound)
+}
+
+func TestAccuracyAndExport(t *testing.T) {
+	db := useTestDB(t)
+	analyses := seedFeedbackData(t, db)
+	feedback := NewFeedbackService()
+	ctx := context.Background()
+	for i, rating := range []string{model.FeedbackCorrect, model.FeedbackPartially, model.FeedbackWrong, model.FeedbackCorrect} {
+		_, err := feedback.Rate(ctx, analyses[i].ID, FeedbackInput{Rating: rating, RootCause: "actual " + rating})
+		require.NoError(t, err)
+	}
+
+	byModel, err := feedback.Accuracy(ctx, AccuracyByModel, FeedbackFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []AccuracyGroup{
+		{Model: "deepseek", Total: 2, Correct: 1, Partially: 1, Accuracy: 0.5, Score: 0.75},
+		{Model: "qwen", Total: 2, Correct: 1, Wrong: 1, Accuracy: 0.5, Score: 0.5},
+	}, byModel)
+
+	byPrompt, err := feedback.Accuracy(ctx, AccuracyByPrompt, FeedbackFilter{Project: "civ"})
+	require.NoError(t, err)
+	assert.Equal(t, []AccuracyGroup{
+		{PromptName: "build_failure", PromptVersion: 1, Total: 1, Correct: 1, Accuracy: 1, Score: 1},
+		{PromptName: "build_failure", PromptVersion: 2, Total: 2, Partially: 1, Wrong: 1, Accuracy: 0, Score: 0.25},
+	}, byPrompt)
+
+	byCategory, err := feedback.Accuracy(ctx, AccuracyByCategory, FeedbackFilter{Model: "qwen"})
+	require.NoError(t, err)
+	require.Len(t, byCategory, 2)
+	assert.Equal(t, "infra", byCategory[0].Category)
+
+	var out bytes.Buffer
+	require.NoError(t, feedback.Export(ctx, FeedbackFilter{Project: "civ", PromptVersion: 2}, &out))
+	var examples []LabeledExample
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var example LabeledExample
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &example))
+		examples = append(examples, example)
+	}
+	require.Len(t, examples, 2)
+	assert.Equal(t, analyses[1].ID, examples[0].AnalysisID)
+	assert.Equal(t, "civ", examples[0].Project)
+	assert.Equal(t, "build", examples[0].JobName)
+	assert.Equal(t, "abc1234", examples[0].CommitSHA)
+	assert.Equal(t, model.FeedbackPartially, examples[0].Rating)
+	assert.Equal(t, "actual partially", examples[0].ActualRootCause)
+	assert.False(t, examples[0].RatedAt.IsZero())
+}
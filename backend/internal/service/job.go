@@ -0,0 +1,85 @@
+package service
+
+import (
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/query"
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobListRules 任务列表允许的过滤和排序字段
+var JobListRules = query.Rules{
+	Filters: map[string]query.Field{
+		"project":     {Column: "project"},
+		"branch":      {Column: "branch"},
+		"status":      {Column: "status"},
+		"commit_sha":  {Column: "commit_sha"},
+		"started_at":  {Column: "started_at", Type: query.Time},
+		"finished_at": {Column: "finished_at", Type: query.Time},
+	},
+	Sorts: map[string]string{
+		"id":          "id",
+		"name":        "name",
+		"started_at":  "started_at",
+		"finished_at": "finished_at",
+	},
+	DefaultSort: "-id",
+}
+
+type JobService interface {
+	Get(ctx context.Context, id uint) (*model.Job, error)
+	// List 按 JobListRules 解析出的条件分页查询，返回当前页和总数
+	List(ctx context.Context, spec *query.Spec) ([]model.Job, int64, error)
+	// Upsert 按 (Project, ExternalID) 新增或更新任务，用于从 CI 系统导入
+	Upsert(ctx context.Context, job *model.Job) error
+}
+
+type jobServiceImpl struct {
+	db *gorm.DB
+}
+
+func NewJobService() JobService {
+	return &jobServiceImpl{db: data.DB}
+}
+
+func (s *jobServiceImpl) Get(ctx context.Context, id uint) (*model.Job, error) {
+	var job model.Job
+	err := s.db.WithContext(ctx).First(&job, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.NewBusinessError(errors.JobDoesNotExist).WithParam("id", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (s *jobServiceImpl) List(ctx context.Context, spec *query.Spec) ([]model.Job, int64, error) {
+	// Session 使 Count 和 Find 各自从同一条件开始，互不带入对方的子句
+	db := s.db.WithContext(ctx).Model(&model.Job{}).Session(&gorm.Session{})
+	var total int64
+	if err := db.Scopes(spec.Filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	jobs := make([]model.Job, 0, spec.Size)
+	if err := db.Scopes(spec.Filter, spec.Sort, spec.Paginate).Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+func (s *jobServiceImpl) Upsert(ctx context.Context, job *model.Job) error {
+	if job.Project == "" || job.ExternalID == "" || job.Name == "" {
+		return errors.NewBusinessError(errors.InvalidParameter)
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "project"}, {Name: "external_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"name", "branch", "commit_sha", "status", "log_path", "started_at", "finished_at", "updated_at",
+		}),
+	}).Create(job).Error
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"civ/internal/model"
+	"civ/internal/pkg/query"
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListJobs(t *testing.T) {
+	useTestDB(t)
+	ctx := context.Background()
+	jobs := NewJobService()
+	for i, status := range []string{model.JobFailed, model.JobSuccess, model.JobFailed, model.JobFailed} {
+		require.NoError(t, jobs.Upsert(ctx, &model.Job{
+			Project:    "civ",
+			ExternalID: fmt.Sprint(i),
+			Name:       "build",
+			Branch:     "main",
+			Status:     status,
+		}))
+	}
+
+	values, _ := url.ParseQuery("status=failed&size=2")
+	spec, err := query.Parse(values, JobListRules)
+	require.NoError(t, err)
+	page, total, err := jobs.List(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, page, 2)
+	assert.Equal(t, "3", page[0].ExternalID, "newest first by default")
+	assert.Equal(t, "2", page[1].ExternalID)
+}
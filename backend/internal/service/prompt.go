@@ -0,0 +1,353 @@
+package service
+
+import (
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/query"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gorm.io/gorm"
+)
+
+// PromptListRules 提示词模板列表允许的过滤和排序字段
+var PromptListRules = query.Rules{
+	Filters: map[string]query.Field{
+		"name":    {Column: "name"},
+		"project": {Column: "project"},
+	},
+	Sorts: map[string]string{
+		"id":         "id",
+		"name":       "name",
+		"updated_at": "updated_at",
+	},
+	DefaultSort: "name",
+}
+
+// PromptInput 新建模板版本的内容
+type PromptInput struct {
+	Body      string
+	Variables []model.PromptVariable
+	Comment   string
+}
+
+// PromptDetail 模板及其当前使用的版本
+type PromptDetail struct {
+	model.PromptTemplate
+	Active *model.PromptVersion `json:"active"`
+}
+
+// ResolvedPrompt 为一次分析渲染好的提示词
+type ResolvedPrompt struct {
+	TemplateID uint
+	Name       string
+	Version    int
+	Text       string
+}
+
+type PromptService interface {
+	// List 按 PromptListRules 解析出的条件分页查询模板
+	List(ctx context.Context, spec *query.Spec) ([]model.PromptTemplate, int64, error)
+	Get(ctx context.Context, id uint) (*PromptDetail, error)
+	// Create 新建模板及其第 1 个版本，并将该版本设为当前版本
+	Create(ctx context.Context, tpl *model.PromptTemplate, input PromptInput) (*PromptDetail, error)
+	// Update 修改模板描述，模板内容通过新增版本修改
+	Update(ctx context.Context, id uint, description string) (*model.PromptTemplate, error)
+	// Delete 删除模板及其全部版本
+	Delete(ctx context.Context, id uint) error
+	// ListVersions 返回模板的全部版本，新版本在前
+	ListVersions(ctx context.Context, id uint) ([]model.PromptVersion, error)
+	// AddVersion 新增版本，版本号递增；activate 为 true 时同时设为当前版本
+	AddVersion(ctx context.Context, id uint, input PromptInput, activate bool) (*model.PromptVersion, error)
+	// Activate 将已有版本设为当前版本，可用于回滚
+	Activate(ctx context.Context, id uint, version int) (*PromptDetail, error)
+	// Resolve 渲染 project 使用的 name 模板的当前版本：项目有同名模板时优先，否则使用全局模板。
+	// 模板不存在或没有当前版本时返回 nil
+	Resolve(ctx context.Context, name, project string, values map[string]any) (*ResolvedPrompt, error)
+}
+
+type promptServiceImpl struct {
+	db *gorm.DB
+}
+
+func NewPromptService() PromptService {
+	return &promptServiceImpl{db: data.DB}
+}
+
+func (s *promptServiceImpl) List(ctx context.Context, spec *query.Spec) ([]model.PromptTemplate, int64, error) {
+	// Session 使 Count 和 Find 各自从同一条件开始，互不带入对方的子句
+	db := s.db.WithContext(ctx).Model(&model.PromptTemplate{}).Scopes(spec.Filter).Session(&gorm.Session{})
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var templates []model.PromptTemplate
+	err := db.Scopes(spec.Sort, spec.Paginate).Find(&templates).Error
+	return templates, total, err
+}
+
+func (s *promptServiceImpl) Get(ctx context.Context, id uint) (*PromptDetail, error) {
+	return s.detail(s.db.WithContext(ctx), id)
+}
+
+func (s *promptServiceImpl) detail(db *gorm.DB, id uint) (*PromptDetail, error) {
+	tpl, err := s.template(db, id)
+	if err != nil {
+		return nil, err
+	}
+	detail := &PromptDetail{PromptTemplate: *tpl}
+	if tpl.ActiveVersion > 0 {
+		if detail.Active, err = s.version(db, tpl.ID, tpl.ActiveVersion); err != nil {
+			return nil, err
+		}
+	}
+	return detail, nil
+}
+
+func (s *promptServiceImpl) template(db *gorm.DB, id uint) (*model.PromptTemplate, error) {
+	var tpl model.PromptTemplate
+	err := db.First(&tpl, id).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.NewBusinessError(errors.PromptDoesNotExist).WithParam("id", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+func (s *promptServiceImpl) version(db *gorm.DB, templateID uint, version int) (*model.PromptVersion, error) {
+	var v model.PromptVersion
+	err := db.Where("template_id = ? AND version = ?", templateID, version).First(&v).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.NewBusinessError(errors.PromptVersionDoesNotExist).WithParam("id", templateID).WithParam("version", version)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (s *promptServiceImpl) Create(ctx context.Context, tpl *model.PromptTemplate, input PromptInput) (*PromptDetail, error) {
+	if err := validatePrompt(input); err != nil {
+		return nil, err
+	}
+	var detail *PromptDetail
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tpl.ActiveVersion = 1
+		// 同名模板由唯一索引判定，并发创建时只有一个成功
+		err := tx.Create(tpl).Error
+		if stderrors.Is(err, gorm.ErrDuplicatedKey) {
+			return errors.NewBusinessError(errors.PromptAlreadyExists).WithParam("name", tpl.Name)
+		}
+		if err != nil {
+			return err
+		}
+		version := &model.PromptVersion{TemplateID: tpl.ID, Version: 1, Body: input.Body, Variables: input.Variables, Comment: input.Comment}
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		detail = &PromptDetail{PromptTemplate: *tpl, Active: version}
+		return nil
+	})
+	return detail, err
+}
+
+func (s *promptServiceImpl) Update(ctx context.Context, id uint, description string) (*model.PromptTemplate, error) {
+	db := s.db.WithContext(ctx)
+	tpl, err := s.template(db, id)
+	if err != nil {
+		return nil, err
+	}
+	tpl.Description = description
+	return tpl, db.Save(tpl).Error
+}
+
+func (s *promptServiceImpl) Delete(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := s.template(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Where("template_id = ?", id).Delete(&model.PromptVersion{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.PromptTemplate{}, id).Error
+	})
+}
+
+func (s *promptServiceImpl) ListVersions(ctx context.Context, id uint) ([]model.PromptVersion, error) {
+	db := s.db.WithContext(ctx)
+	if _, err := s.template(db, id); err != nil {
+		return nil, err
+	}
+	var versions []model.PromptVersion
+	err := db.Where("template_id = ?", id).Order("version DESC").Find(&versions).Error
+	return versions, err
+}
+
+func (s *promptServiceImpl) AddVersion(ctx context.Context, id uint, input PromptInput, activate bool) (*model.PromptVersion, error) {
+	if err := validatePrompt(input); err != nil {
+		return nil, err
+	}
+	var version *model.PromptVersion
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tpl, err := s.template(tx, id)
+		if err != nil {
+			return err
+		}
+		var latest int
+		if err := tx.Model(&model.PromptVersion{}).Where("template_id = ?", id).Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		version = &model.PromptVersion{TemplateID: id, Version: latest + 1, Body: input.Body, Variables: input.Variables, Comment: input.Comment}
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+		if !activate {
+			return nil
+		}
+		tpl.ActiveVersion = version.Version
+		return tx.Save(tpl).Error
+	})
+	return version, err
+}
+
+func (s *promptServiceImpl) Activate(ctx context.Context, id uint, version int) (*PromptDetail, error) {
+	var detail *PromptDetail
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tpl, err := s.template(tx, id)
+		if err != nil {
+			return err
+		}
+		active, err := s.version(tx, id, version)
+		if err != nil {
+			return err
+		}
+		tpl.ActiveVersion = version
+		if err := tx.Save(tpl).Error; err != nil {
+			return err
+		}
+		detail = &PromptDetail{PromptTemplate: *tpl, Active: active}
+		return nil
+	})
+	return detail, err
+}
+
+func (s *promptServiceImpl) Resolve(ctx context.Context, name, project string, values map[string]any) (*ResolvedPrompt, error) {
+	if name == "" {
+		return nil, nil
+	}
+	db := s.db.WithContext(ctx)
+	var templates []model.PromptTemplate
+	err := db.Where("name = ? AND project IN ? AND active_version > 0", name, []string{project, ""}).
+		Order("project DESC").Limit(1).Find(&templates).Error
+	if err != nil || len(templates) == 0 {
+		return nil, err
+	}
+	tpl := templates[0]
+	version, err := s.version(db, tpl.ID, tpl.ActiveVersion)
+	if err != nil {
+		return nil, err
+	}
+	text, err := renderPrompt(version.Body, version.Variables, values)
+	if err != nil {
+		return nil, errors.Wrap(errors.PromptInvalid, err).WithParam("reason", err.Error())
+	}
+	return &ResolvedPrompt{TemplateID: tpl.ID, Name: tpl.Name, Version: version.Version, Text: text}, nil
+}
+
+// validatePrompt 检查变量定义，并用各变量的默认值（没有默认值时用类型零值）试渲染，
+// 确保模板语法正确且只引用了声明的变量
+func validatePrompt(input PromptInput) error {
+	invalid := func(reason string) error {
+		return errors.NewBusinessError(errors.PromptInvalid).WithParam("reason", reason)
+	}
+	sample := make(map[string]any, len(input.Variables))
+	for _, v := range input.Variables {
+		if _, ok := sample[v.Name]; ok {
+			return invalid(fmt.Sprintf("variable %s is declared twice", v.Name))
+		}
+		value, err := variableValue(v, cmpDefault(v.Default, zeroValue(v.Type)))
+		if err != nil {
+			return invalid(err.Error())
+		}
+		sample[v.Name] = value
+	}
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(input.Body)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	if err := tmpl.Execute(&strings.Builder{}, sample); err != nil {
+		return invalid(err.Error())
+	}
+	return nil
+}
+
+// renderPrompt 只把声明的变量传给模板，缺失的必填变量报错，缺失的可选变量使用默认值
+func renderPrompt(body string, variables []model.PromptVariable, values map[string]any) (string, error) {
+	data := make(map[string]any, len(variables))
+	for _, v := range variables {
+		value, ok := values[v.Name]
+		if !ok {
+			if v.Required {
+				return "", fmt.Errorf("required variable %s is missing", v.Name)
+			}
+			var err error
+			if value, err = variableValue(v, cmpDefault(v.Default, zeroValue(v.Type))); err != nil {
+				return "", err
+			}
+		}
+		data[v.Name] = value
+	}
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func variableValue(v model.PromptVariable, raw string) (any, error) {
+	switch v.Type {
+	case model.PromptVarInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("default of variable %s is not an int", v.Name)
+		}
+		return n, nil
+	case model.PromptVarBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("default of variable %s is not a bool", v.Name)
+		}
+		return b, nil
+	case model.PromptVarString:
+		return raw, nil
+	}
+	return nil, stderrors.New("unknown type " + v.Type + " of variable " + v.Name)
+}
+
+func zeroValue(typ string) string {
+	switch typ {
+	case model.PromptVarInt:
+		return "0"
+	case model.PromptVarBool:
+		return "false"
+	}
+	return ""
+}
+
+func cmpDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
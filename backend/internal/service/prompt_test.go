@@ -0,0 +1,114 @@
+package service
+
+import (
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func requireCode(t *testing.T, err error, code int, msgAndArgs ...any) {
+	t.Helper()
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError, msgAndArgs...)
+	assert.Equal(t, code, businessError.GetCode(), msgAndArgs...)
+}
+
+func TestPromptVersionsAndActivation(t *testing.T) {
+	useTestDB(t)
+	prompts := NewPromptService()
+	ctx := context.Background()
+
+	detail, err := prompts.Create(ctx, &model.PromptTemplate{Name: "build_failure"}, PromptInput{
+		Body: "Analyze {{.job_name}}", Variables: []model.PromptVariable{{Name: "job_name", Type: model.PromptVarString}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, detail.ActiveVersion)
+	assert.Equal(t, "Analyze {{.job_name}}", detail.Active.Body)
+
+	_, err = prompts.Create(ctx, &model.PromptTemplate{Name: "build_failure"}, PromptInput{Body: "again"})
+	requireCode(t, err, errors.PromptAlreadyExists)
+
+	v2, err := prompts.AddVersion(ctx, detail.ID, PromptInput{Body: "v2"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v2.Version)
+	got, err := prompts.Get(ctx, detail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.ActiveVersion, "adding a version without activate keeps the active one")
+
+	v3, err := prompts.AddVersion(ctx, detail.ID, PromptInput{Body: "v3"}, true)
+	require.NoError(t, err)
+	got, err = prompts.Get(ctx, detail.ID)
+	require.NoError(t, err)
+	assert.Equal(t, v3.Version, got.Active.Version)
+
+	got, err = prompts.Activate(ctx, detail.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Analyze {{.job_name}}", got.Active.Body)
+	_, err = prompts.Activate(ctx, detail.ID, 9)
+	requireCode(t, err, errors.PromptVersionDoesNotExist)
+
+	versions, err := prompts.ListVersions(ctx, detail.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	assert.Equal(t, 3, versions[0].Version)
+
+	require.NoError(t, prompts.Delete(ctx, detail.ID))
+	_, err = prompts.ListVersions(ctx, detail.ID)
+	requireCode(t, err, errors.PromptDoesNotExist)
+}
+
+func TestPromptValidation(t *testing.T) {
+	useTestDB(t)
+	prompts := NewPromptService()
+	ctx := context.Background()
+
+	for name, input := range map[string]PromptInput{
+		"syntax error":        {Body: "{{.project"},
+		"undeclared variable": {Body: "{{.project}}"},
+		"bad default":         {Body: "{{.depth}}", Variables: []model.PromptVariable{{Name: "depth", Type: model.PromptVarInt, Default: "deep"}}},
+		"duplicate variable": {Body: "{{.a}}", Variables: []model.PromptVariable{
+			{Name: "a", Type: model.PromptVarString}, {Name: "a", Type: model.PromptVarInt},
+		}},
+	} {
+		_, err := prompts.Create(ctx, &model.PromptTemplate{Name: "p"}, input)
+		requireCode(t, err, errors.PromptInvalid, name)
+	}
+}
+
+func TestPromptResolve(t *testing.T) {
+	useTestDB(t)
+	prompts := NewPromptService()
+	ctx := context.Background()
+	variables := []model.PromptVariable{
+		{Name: "project", Type: model.PromptVarString, Required: true},
+		{Name: "depth", Type: model.PromptVarInt, Default: "3"},
+	}
+
+	resolved, err := prompts.Resolve(ctx, "build_failure", "civ", nil)
+	require.NoError(t, err)
+	assert.Nil(t, resolved, "no template falls back to the agent's builtin prompt")
+
+	global, err := prompts.Create(ctx, &model.PromptTemplate{Name: "build_failure"}, PromptInput{
+		Body: "Project {{.project}}, depth {{.depth}}", Variables: variables,
+	})
+	require.NoError(t, err)
+	_, err = prompts.Create(ctx, &model.PromptTemplate{Name: "build_failure", Project: "civ"}, PromptInput{
+		Body: "Override for {{.project}}", Variables: variables,
+	})
+	require.NoError(t, err)
+
+	resolved, err = prompts.Resolve(ctx, "build_failure", "other", map[string]any{"project": "other", "job_id": 7})
+	require.NoError(t, err)
+	assert.Equal(t, &ResolvedPrompt{TemplateID: global.ID, Name: "build_failure", Version: 1, Text: "Project other, depth 3"}, resolved)
+
+	resolved, err = prompts.Resolve(ctx, "build_failure", "civ", map[string]any{"project": "civ"})
+	require.NoError(t, err)
+	assert.Equal(t, "Override for civ", resolved.Text)
+
+	_, err = prompts.Resolve(ctx, "build_failure", "other", map[string]any{})
+	requireCode(t, err, errors.PromptInvalid)
+}
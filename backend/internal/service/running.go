@@ -0,0 +1,85 @@
+package service
+
+import (
+	"civ/internal/agent"
+	"civ/internal/pkg/metrics"
+	"context"
+	stderrors "errors"
+	"sync"
+)
+
+// errAnalysisCanceled 通过 Cancel 取消分析时的取消原因
+var errAnalysisCanceled = stderrors.New("analysis canceled")
+
+// analysisQueue 进行中分析数在 queue_depth 指标中的队列名
+const analysisQueue = "analyses"
+
+// runningAnalyses 本实例上进行中的分析，用于查询进度和取消
+var runningAnalyses = &analysisTracker{analyses: make(map[uint]*trackedAnalysis)}
+
+type trackedAnalysis struct {
+	cancel   context.CancelCauseFunc
+	progress agent.StreamProgress
+}
+
+type analysisTracker struct {
+	mu       sync.Mutex
+	analyses map[uint]*trackedAnalysis
+}
+
+// start 登记进行中的分析并返回可取消的 ctx；done 注销分析，返回分析是否被 Cancel 取消
+func (t *analysisTracker) start(ctx context.Context, id uint) (context.Context, func() (canceled bool)) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	t.mu.Lock()
+	t.analyses[id] = &trackedAnalysis{cancel: cancel}
+	metrics.SetQueueDepth(analysisQueue, len(t.analyses))
+	t.mu.Unlock()
+	return ctx, func() bool {
+		t.mu.Lock()
+		delete(t.analyses, id)
+		metrics.SetQueueDepth(analysisQueue, len(t.analyses))
+		t.mu.Unlock()
+		canceled := context.Cause(ctx) == errAnalysisCanceled
+		cancel(nil)
+		return canceled
+	}
+}
+
+func (t *analysisTracker) len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.analyses)
+}
+
+// RunningAnalyses 返回本实例上进行中的分析数
+func RunningAnalyses() int {
+	return runningAnalyses.len()
+}
+
+func (t *analysisTracker) update(id uint, p agent.StreamProgress) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if a, ok := t.analyses[id]; ok {
+		a.progress = p
+	}
+}
+
+func (t *analysisTracker) progress(id uint) (agent.StreamProgress, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a, ok := t.analyses[id]
+	if !ok {
+		return agent.StreamProgress{}, false
+	}
+	return a.progress, true
+}
+
+func (t *analysisTracker) cancel(id uint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	a, ok := t.analyses[id]
+	if ok {
+		a.cancel(errAnalysisCanceled)
+	}
+	return ok
+}
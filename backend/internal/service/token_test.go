@@ -0,0 +1,42 @@
+package service
+
+import (
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticateToken(t *testing.T) {
+	db := useTestDB(t)
+	ctx := context.Background()
+	user, err := NewUserService().Create(ctx, "alice", "", "pa55word", "")
+	require.NoError(t, err)
+	tokens := NewTokenService()
+
+	plain, issued, err := tokens.Issue(ctx, "alice", "ci", time.Hour)
+	require.NoError(t, err)
+	token, err := tokens.Authenticate(ctx, plain)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, token.UserID)
+	var stored model.APIToken
+	require.NoError(t, db.First(&stored, issued.ID).Error)
+	assert.NotNil(t, stored.LastUsedAt)
+
+	_, err = tokens.Authenticate(ctx, plain+"0")
+	requireCode(t, err, errors.NotLogin)
+
+	expired, _, err := tokens.Issue(ctx, "alice", "expired", time.Nanosecond)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = tokens.Authenticate(ctx, expired)
+	requireCode(t, err, errors.NotLogin)
+
+	require.NoError(t, db.Model(&model.APIToken{}).Where("id = ?", issued.ID).Update("revoked_at", time.Now()).Error)
+	_, err = tokens.Authenticate(ctx, plain)
+	requireCode(t, err, errors.NotLogin)
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"civ/config"
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRecentRuns   = 10
+	maxRecentRuns       = 50
+	maxToolLogBytes     = 1 << 20
+	defaultDiffBytes    = 256 << 10
+	defaultSimilarLimit = 5
+	maxSimilarFailures  = 20
+	commitDiffTimeout   = 10 * time.Second
+)
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,64}$`)
+
+// SimilarFailure 同项目中已有分析结果的失败
+type SimilarFailure struct {
+	Job      model.Job
+	Analysis model.Analysis
+}
+
+// CommitDiff git show 的输出，超过上限时被截断
+type CommitDiff struct {
+	SHA       string
+	Diff      string
+	Truncated bool
+}
+
+// ToolService 代理在分析过程中调用的只读工具。所有查询都限定在 scope 的项目内，
+// 访问其他项目的数据返回 AuthorizationError
+type ToolService interface {
+	// Authorize 校验工具令牌，令牌对应的分析必须仍在进行中，失败时返回 NotLogin
+	Authorize(ctx context.Context, token string) (*ToolScope, error)
+	// GetJob jobID 为 0 时返回被分析的任务
+	GetJob(ctx context.Context, scope *ToolScope, jobID uint) (*model.Job, error)
+	// ListRecentRuns 同名任务最近的运行，name 为空时取被分析任务的任务名，branch 为空时不限分支
+	ListRecentRuns(ctx context.Context, scope *ToolScope, name, branch string, limit int) ([]model.Job, error)
+	// GetLogRange 读取任务日志 [offset, offset+length)，length 最多 1 MiB，返回内容和日志总大小
+	GetLogRange(ctx context.Context, scope *ToolScope, jobID uint, offset, length int64) ([]byte, int64, error)
+	// GetCommitDiff 在 storage.repo_dir 下项目的 git 仓库中执行 git show，sha 为空时取被分析任务的提交
+	GetCommitDiff(ctx context.Context, scope *ToolScope, sha string, maxBytes int) (*CommitDiff, error)
+	// SearchSimilarFailures 在同项目成功的分析中按关键字和分类查找，最新的在前
+	SearchSimilarFailures(ctx context.Context, scope *ToolScope, query, category string, limit int) ([]SimilarFailure, error)
+}
+
+type toolServiceImpl struct {
+	db      *gorm.DB
+	now     func() time.Time
+	repoDir string
+}
+
+func NewToolService() ToolService {
+	return &toolServiceImpl{db: data.DB, now: time.Now, repoDir: config.GetConfig().Storage.RepoDir}
+}
+
+func (s *toolServiceImpl) Authorize(ctx context.Context, token string) (*ToolScope, error) {
+	scope, ok := parseToolToken(token, s.now())
+	if !ok {
+		return nil, errors.NewBusinessError(errors.NotLogin)
+	}
+	var analysis model.Analysis
+	err := s.db.WithContext(ctx).Select("id", "job_id", "status").First(&analysis, scope.AnalysisID).Error
+	if err == gorm.ErrRecordNotFound || (err == nil && (analysis.Status != model.AnalysisRunning || analysis.JobID != scope.JobID)) {
+		return nil, errors.NewBusinessError(errors.NotLogin)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
+
+func (s *toolServiceImpl) GetJob(ctx context.Context, scope *ToolScope, jobID uint) (*model.Job, error) {
+	if jobID == 0 {
+		jobID = scope.JobID
+	}
+	job, err := NewJobService().Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Project != scope.Project {
+		return nil, errors.NewBusinessError(errors.AuthorizationError)
+	}
+	return job, nil
+}
+
+func (s *toolServiceImpl) ListRecentRuns(ctx context.Context, scope *ToolScope, name, branch string, limit int) ([]model.Job, error) {
+	if name == "" {
+		job, err := s.GetJob(ctx, scope, 0)
+		if err != nil {
+			return nil, err
+		}
+		name = job.Name
+	}
+	if limit <= 0 {
+		limit = defaultRecentRuns
+	}
+	db := s.db.WithContext(ctx).Where("project = ? AND name = ?", scope.Project, name)
+	if branch != "" {
+		db = db.Where("branch = ?", branch)
+	}
+	var jobs []model.Job
+	err := db.Order("started_at DESC").Order("id DESC").Limit(min(limit, maxRecentRuns)).Find(&jobs).Error
+	return jobs, err
+}
+
+func (s *toolServiceImpl) GetLogRange(ctx context.Context, scope *ToolScope, jobID uint, offset, length int64) ([]byte, int64, error) {
+	if offset < 0 || length <= 0 {
+		return nil, 0, errors.NewBusinessError(errors.InvalidParameter)
+	}
+	job, err := s.GetJob(ctx, scope, jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+	log, closeLog, err := openLog(resolveLogPath(job.LogPath))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer closeLog()
+
+	offset = min(offset, log.Size())
+	buf := make([]byte, min(length, maxToolLogBytes, log.Size()-offset))
+	n, err := log.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return nil, 0, err
+	}
+	return buf[:n], log.Size(), nil
+}
+
+func (s *toolServiceImpl) GetCommitDiff(ctx context.Context, scope *ToolScope, sha string, maxBytes int) (*CommitDiff, error) {
+	if sha == "" {
+		job, err := s.GetJob(ctx, scope, 0)
+		if err != nil {
+			return nil, err
+		}
+		sha = job.CommitSHA
+	}
+	notFound := errors.NewBusinessError(errors.CommitDoesNotExist).WithParam("sha", sha).WithParam("project", scope.Project)
+	if !commitSHAPattern.MatchString(sha) {
+		return nil, notFound
+	}
+	repo, ok := projectRepo(s.repoDir, scope.Project)
+	if !ok {
+		return nil, notFound
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultDiffBytes
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commitDiffTimeout)
+	defer cancel()
+	if err := exec.CommandContext(ctx, "git", "-C", repo, "cat-file", "-e", sha+"^{commit}").Run(); err != nil {
+		return nil, notFound
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "-C", repo, "show", "--no-color", "--stat", "--patch", "--format=fuller", sha, "--")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s in %s: %w: %s", sha, repo, err, strings.TrimSpace(stderr.String()))
+	}
+	diff := &CommitDiff{SHA: sha, Diff: string(out)}
+	if len(out) > maxBytes {
+		diff.Diff, diff.Truncated = string(out[:maxBytes]), true
+	}
+	return diff, nil
+}
+
+// projectRepo 返回项目在 root 下的仓库目录，未配置或项目名试图跳出该目录时返回 false
+func projectRepo(root, project string) (string, bool) {
+	if root == "" || project == "" {
+		return "", false
+	}
+	repo := filepath.Join(root, project)
+	if rel, err := filepath.Rel(root, repo); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if _, err := os.Stat(repo); err != nil {
+		return "", false
+	}
+	return repo, true
+}
+
+func (s *toolServiceImpl) SearchSimilarFailures(ctx context.Context, scope *ToolScope, query, category string, limit int) ([]SimilarFailure, error) {
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	db := s.db.WithContext(ctx).
+		Table("analyses").
+		Select("analyses.*").
+		Joins("JOIN jobs ON jobs.id = analyses.job_id").
+		Where("jobs.project = ? AND analyses.status = ? AND analyses.id <> ?", scope.Project, model.AnalysisSucceeded, scope.AnalysisID)
+	if category != "" {
+		db = db.Where("analyses.category = ?", category)
+	}
+	for _, term := range strings.Fields(query) {
+		like := "%" + escapeLike(strings.ToLower(term)) + "%"
+		db = db.Where("(LOWER(analyses.summary) LIKE ? ESCAPE '!' OR LOWER(analyses.root_cause) LIKE ? ESCAPE '!')", like, like)
+	}
+	var analyses []model.Analysis
+	if err := db.Order("analyses.id DESC").Limit(min(limit, maxSimilarFailures)).Find(&analyses).Error; err != nil {
+		return nil, err
+	}
+	if len(analyses) == 0 {
+		return nil, nil
+	}
+
+	jobIDs := make([]uint, 0, len(analyses))
+	for _, a := range analyses {
+		jobIDs = append(jobIDs, a.JobID)
+	}
+	var jobs []model.Job
+	if err := s.db.WithContext(ctx).Find(&jobs, jobIDs).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]model.Job, len(jobs))
+	for _, j := range jobs {
+		byID[j.ID] = j
+	}
+	failures := make([]SimilarFailure, 0, len(analyses))
+	for _, a := range analyses {
+		failures = append(failures, SimilarFailure{Job: byID[a.JobID], Analysis: a})
+	}
+	return failures, nil
+}
+
+// escapeLike 转义 LIKE 通配符，配合 ESCAPE '!' 使用。不用反斜杠作转义符：
+// MySQL 默认 sql_mode 下字符串中的 '\' 会吞掉结尾的引号
+func escapeLike(s string) string {
+	return strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`).Replace(s)
+}
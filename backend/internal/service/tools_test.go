@@ -0,0 +1,148 @@
+package service
+
+import (
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedToolData 创建两个项目的任务，civ 项目的 build 任务有一条进行中的分析和一条历史分析
+func seedToolData(t *testing.T, db *gorm.DB) (*ToolScope, string) {
+	t.Helper()
+	logPath := filepath.Join(t.TempDir(), "build.log")
+	require.NoError(t, os.WriteFile(logPath, []byte("step 1\nerror: undefined: foo\n"), 0o644))
+	jobs := []model.Job{
+		{Project: "civ", ExternalID: "1", Name: "build", Branch: "main", Status: model.JobFailed},
+		{Project: "civ", ExternalID: "2", Name: "build", Branch: "dev", Status: model.JobSuccess},
+		{Project: "civ", ExternalID: "3", Name: "build", Branch: "main", Status: model.JobFailed, LogPath: logPath, CommitSHA: "abc1234"},
+		{Project: "other", ExternalID: "1", Name: "build", Status: model.JobFailed},
+	}
+	require.NoError(t, db.Create(&jobs).Error)
+	require.NoError(t, db.Create(&[]model.Analysis{
+		{JobID: jobs[0].ID, Status: model.AnalysisSucceeded, Summary: "Compile error in foo.go", RootCause: "undefined: foo", Category: "compile"},
+		{JobID: jobs[3].ID, Status: model.AnalysisSucceeded, Summary: "Compile error", RootCause: "undefined: foo", Category: "compile"},
+		{JobID: jobs[2].ID, Status: model.AnalysisRunning},
+	}).Error)
+	scope := &ToolScope{AnalysisID: 3, JobID: jobs[2].ID, Project: "civ"}
+	return scope, issueToolToken(*scope, time.Now())
+}
+
+func TestToolTokenAuthorization(t *testing.T) {
+	db := useTestDB(t)
+	scope, token := seedToolData(t, db)
+	tools := NewToolService()
+	ctx := context.Background()
+
+	got, err := tools.Authorize(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, scope, got)
+
+	expired := issueToolToken(*scope, time.Now().Add(-2*toolTokenTTL))
+	otherAnalysis := issueToolToken(ToolScope{AnalysisID: 1, JobID: 1, Project: "civ"}, time.Now())
+	for name, token := range map[string]string{
+		"empty":             "",
+		"tampered":          token[:len(token)-2] + "xx",
+		"expired":           expired,
+		"finished analysis": otherAnalysis,
+	} {
+		_, err := tools.Authorize(ctx, token)
+		var businessError *errors.BusinessError
+		require.ErrorAs(t, err, &businessError, name)
+		assert.Equal(t, errors.NotLogin, businessError.GetCode(), name)
+	}
+
+	require.NoError(t, db.Model(&model.Analysis{}).Where("id = ?", 3).Update("status", model.AnalysisSucceeded).Error)
+	_, err = tools.Authorize(ctx, token)
+	assert.Error(t, err, "token expires with the analysis")
+}
+
+func TestToolsAreScopedToProject(t *testing.T) {
+	db := useTestDB(t)
+	scope, _ := seedToolData(t, db)
+	tools := NewToolService()
+	ctx := context.Background()
+
+	job, err := tools.GetJob(ctx, scope, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "3", job.ExternalID)
+
+	_, err = tools.GetJob(ctx, scope, 4)
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.AuthorizationError, businessError.GetCode())
+
+	runs, err := tools.ListRecentRuns(ctx, scope, "", "main", 0)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "3", runs[0].ExternalID)
+
+	data, size, err := tools.GetLogRange(ctx, scope, 0, 7, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "error: undefined: foo\n", string(data))
+	assert.Equal(t, int64(29), size)
+
+	failures, err := tools.SearchSimilarFailures(ctx, scope, "UNDEFINED foo", "compile", 0)
+	require.NoError(t, err)
+	require.Len(t, failures, 1, "only analyses of the same project")
+	assert.Equal(t, "1", failures[0].Job.ExternalID)
+	assert.Equal(t, "Compile error in foo.go", failures[0].Analysis.Summary)
+
+	for _, query := range []string{"foo%go", "in_foo", "foo!"} {
+		failures, err = tools.SearchSimilarFailures(ctx, scope, query, "", 0)
+		require.NoError(t, err)
+		assert.Empty(t, failures, "%q matches wildcards literally", query)
+	}
+}
+
+func TestGetCommitDiff(t *testing.T) {
+	db := useTestDB(t)
+	seedToolData(t, db)
+	root := t.TempDir()
+	repo := filepath.Join(root, "civ")
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=ci", "-c", "user.email=ci@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+	require.NoError(t, os.MkdirAll(repo, 0o755))
+	git("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n\nfunc main() { foo() }\n"), 0o644))
+	git("add", ".")
+	git("commit", "-q", "-m", "call foo")
+	sha := git("rev-parse", "HEAD")[:12]
+
+	tools := &toolServiceImpl{db: db, now: time.Now, repoDir: root}
+	ctx := context.Background()
+	diff, err := tools.GetCommitDiff(ctx, &ToolScope{AnalysisID: 3, JobID: 3, Project: "civ"}, sha, 0)
+	require.NoError(t, err)
+	assert.Contains(t, diff.Diff, "call foo")
+	assert.Contains(t, diff.Diff, "+func main() { foo() }")
+	assert.False(t, diff.Truncated)
+
+	diff, err = tools.GetCommitDiff(ctx, &ToolScope{AnalysisID: 3, JobID: 3, Project: "civ"}, sha, 10)
+	require.NoError(t, err)
+	assert.Len(t, diff.Diff, 10)
+	assert.True(t, diff.Truncated)
+
+	for _, tc := range []struct{ project, sha string }{
+		{"civ", "abc1234"},
+		{"civ", "HEAD; rm -rf /"},
+		{"../civ", sha},
+		{"other", sha},
+	} {
+		_, err := tools.GetCommitDiff(ctx, &ToolScope{AnalysisID: 3, JobID: 3, Project: tc.project}, tc.sha, 0)
+		var businessError *errors.BusinessError
+		require.ErrorAs(t, err, &businessError, tc)
+		assert.Equal(t, errors.CommitDoesNotExist, businessError.GetCode(), tc)
+	}
+}
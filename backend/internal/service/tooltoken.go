@@ -0,0 +1,91 @@
+package service
+
+import (
+	"civ/config"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// toolTokenTTL 工具令牌的最长有效期，分析结束后令牌随即失效
+const toolTokenTTL = time.Hour
+
+// ToolScope 工具令牌授权的范围：一次分析及其任务所在的项目
+type ToolScope struct {
+	AnalysisID uint
+	JobID      uint
+	Project    string
+}
+
+type toolClaims struct {
+	AnalysisID uint   `json:"a"`
+	JobID      uint   `json:"j"`
+	Project    string `json:"p"`
+	ExpiresAt  int64  `json:"e"`
+}
+
+var (
+	toolKeyOnce sync.Once
+	toolKey     []byte
+)
+
+// toolSecret 返回 grpc.tool_secret。开启 gRPC 服务时配置校验要求设置该值；
+// 未开启时不签发令牌，未配置时使用进程内随机生成的密钥（如测试中）
+func toolSecret() []byte {
+	toolKeyOnce.Do(func() {
+		if secret := config.GetConfig().GRPC.ToolSecret; secret != "" {
+			toolKey = []byte(secret)
+			return
+		}
+		toolKey = make([]byte, 32)
+		if _, err := rand.Read(toolKey); err != nil {
+			panic(fmt.Sprintf("generate tool token key: %v", err))
+		}
+	})
+	return toolKey
+}
+
+// issueToolToken 签发 scope 范围内的工具令牌：base64url(claims).base64url(HMAC-SHA256)
+func issueToolToken(scope ToolScope, now time.Time) string {
+	payload, _ := json.Marshal(toolClaims{
+		AnalysisID: scope.AnalysisID,
+		JobID:      scope.JobID,
+		Project:    scope.Project,
+		ExpiresAt:  now.Add(toolTokenTTL).Unix(),
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signToolToken(encoded))
+}
+
+// parseToolToken 校验签名和有效期，返回令牌中的范围
+func parseToolToken(token string, now time.Time) (*ToolScope, bool) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, signToolToken(encoded)) {
+		return nil, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+	var claims toolClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || now.Unix() > claims.ExpiresAt {
+		return nil, false
+	}
+	return &ToolScope{AnalysisID: claims.AnalysisID, JobID: claims.JobID, Project: claims.Project}, true
+}
+
+func signToolToken(encoded string) []byte {
+	mac := hmac.New(sha256.New, toolSecret())
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
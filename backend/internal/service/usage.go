@@ -0,0 +1,207 @@
+package service
+
+import (
+	"civ/config"
+	"civ/config/autoload"
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"civ/internal/pkg/metrics"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 用量汇总周期和分组
+const (
+	UsageDaily     = "daily"
+	UsageMonthly   = "monthly"
+	UsageByProject = "project"
+	UsageByUser    = "user"
+)
+
+const (
+	dayLayout   = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// UsageQuery 用量汇总条件。From、To 为 2006-01-02 格式的日期（包含），按月汇总时只比较所在月份；
+// 为空时按日汇总默认最近 30 天，按月汇总默认最近 12 个月
+type UsageQuery struct {
+	Period  string
+	GroupBy string
+	From    string
+	To      string
+	Project string
+	UserID  *uint
+}
+
+// UsageTotal 一个周期内一个项目或用户的用量合计，Period 为 2006-01-02 或 2006-01。
+// 按用户汇总时，没有发起人的分析（如 reanalyze 未指定 -user）归入 UserID 为空的一组
+type UsageTotal struct {
+	Period           string  `json:"period"`
+	Project          string  `json:"project,omitempty"`
+	UserID           *uint   `json:"user_id,omitempty"`
+	Analyses         int64   `json:"analyses"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// BudgetStatus 项目今日和本月的费用及上限，上限为 0 表示不限制
+type BudgetStatus struct {
+	Project      string  `json:"project"`
+	Currency     string  `json:"currency"`
+	Daily        float64 `json:"daily"`
+	DailySpent   float64 `json:"daily_spent"`
+	Monthly      float64 `json:"monthly"`
+	MonthlySpent float64 `json:"monthly_spent"`
+}
+
+type UsageService interface {
+	// Record 按 usage.prices 计算费用并保存分析的用量，代理未返回用量时不记录
+	Record(ctx context.Context, job *model.Job, analysis *model.Analysis, usage *analyzer.Usage) (*model.AnalysisUsage, error)
+	// Report 按周期和项目或用户汇总用量
+	Report(ctx context.Context, q UsageQuery) ([]UsageTotal, error)
+	// Budget 返回项目的费用上限和已使用的费用
+	Budget(ctx context.Context, project string) (*BudgetStatus, error)
+	// CheckBudget 项目今日或本月费用达到上限时返回 DailyBudgetExceeded 或 MonthlyBudgetExceeded
+	CheckBudget(ctx context.Context, project string) error
+}
+
+type usageServiceImpl struct {
+	db  *gorm.DB
+	now func() time.Time
+	// config 返回当前的 usage 配置，热加载后价格和上限立即生效
+	config func() autoload.UsageConfig
+}
+
+func NewUsageService() UsageService {
+	return &usageServiceImpl{
+		db:     data.DB,
+		now:    time.Now,
+		config: func() autoload.UsageConfig { return config.GetConfig().Usage },
+	}
+}
+
+func (s *usageServiceImpl) Record(ctx context.Context, job *model.Job, analysis *model.Analysis, usage *analyzer.Usage) (*model.AnalysisUsage, error) {
+	if usage == nil {
+		return nil, nil
+	}
+	modelName := usage.GetModel()
+	if modelName == "" {
+		modelName = analysis.Model
+	}
+	now := s.now()
+	record := &model.AnalysisUsage{
+		AnalysisID:       analysis.ID,
+		Project:          job.Project,
+		RequestedBy:      analysis.RequestedBy,
+		Model:            modelName,
+		PromptTokens:     usage.GetPromptTokens(),
+		CompletionTokens: usage.GetCompletionTokens(),
+		LatencyMs:        usage.GetLatencyMs(),
+		Cost:             cost(s.config().Prices[modelName], usage.GetPromptTokens(), usage.GetCompletionTokens()),
+		Day:              now.Format(dayLayout),
+		Month:            now.Format(monthLayout),
+	}
+	if err := s.db.WithContext(ctx).Create(record).Error; err != nil {
+		return nil, err
+	}
+	metrics.AddLLMTokens(modelName, int(record.PromptTokens), int(record.CompletionTokens))
+	return record, nil
+}
+
+// cost 按每百万 token 单价计算费用
+func cost(price autoload.ModelPrice, promptTokens, completionTokens int64) float64 {
+	return (float64(promptTokens)*price.Prompt + float64(completionTokens)*price.Completion) / 1e6
+}
+
+func (s *usageServiceImpl) Report(ctx context.Context, q UsageQuery) ([]UsageTotal, error) {
+	now := s.now()
+	column, layout, from := "day", dayLayout, now.AddDate(0, 0, -29)
+	if q.Period == UsageMonthly {
+		column, layout, from = "month", monthLayout, now.AddDate(0, -11, 0)
+	}
+	bounds := [2]string{from.Format(layout), now.Format(layout)}
+	for i, date := range []string{q.From, q.To} {
+		if date == "" {
+			continue
+		}
+		t, err := time.ParseInLocation(dayLayout, date, time.Local)
+		if err != nil {
+			return nil, errors.Wrap(errors.InvalidParameter, err)
+		}
+		bounds[i] = t.Format(layout)
+	}
+
+	group, selectGroup := "project", "project"
+	if q.GroupBy == UsageByUser {
+		group, selectGroup = "requested_by", "requested_by AS user_id"
+	}
+	db := s.db.WithContext(ctx).Model(&model.AnalysisUsage{}).
+		Select(column+" AS period, "+selectGroup+", COUNT(*) AS analyses, SUM(prompt_tokens) AS prompt_tokens, "+
+			"SUM(completion_tokens) AS completion_tokens, SUM(cost) AS cost").
+		Where(column+" BETWEEN ? AND ?", bounds[0], bounds[1])
+	if q.Project != "" {
+		db = db.Where("project = ?", q.Project)
+	}
+	if q.UserID != nil {
+		db = db.Where("requested_by = ?", *q.UserID)
+	}
+	totals := []UsageTotal{}
+	err := db.Group(column + ", " + group).Order(column + ", " + group).Scan(&totals).Error
+	return totals, err
+}
+
+func (s *usageServiceImpl) Budget(ctx context.Context, project string) (*BudgetStatus, error) {
+	cfg := s.config()
+	budget := budgetFor(cfg.Budgets, project)
+	status := &BudgetStatus{Project: project, Currency: cfg.Currency, Daily: budget.Daily, Monthly: budget.Monthly}
+	now := s.now()
+	db := s.db.WithContext(ctx).Model(&model.AnalysisUsage{}).Select("COALESCE(SUM(cost), 0)")
+	if err := db.Where("project = ? AND day = ?", project, now.Format(dayLayout)).Scan(&status.DailySpent).Error; err != nil {
+		return nil, err
+	}
+	db = s.db.WithContext(ctx).Model(&model.AnalysisUsage{}).Select("COALESCE(SUM(cost), 0)")
+	if err := db.Where("project = ? AND month = ?", project, now.Format(monthLayout)).Scan(&status.MonthlySpent).Error; err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// budgetFor 返回项目单独配置的上限，没有时返回 project 为空的默认上限
+func budgetFor(budgets []autoload.BudgetConfig, project string) autoload.BudgetConfig {
+	var fallback autoload.BudgetConfig
+	for _, budget := range budgets {
+		if budget.Project == project {
+			return budget
+		}
+		if budget.Project == "" {
+			fallback = budget
+		}
+	}
+	return fallback
+}
+
+func (s *usageServiceImpl) CheckBudget(ctx context.Context, project string) error {
+	status, err := s.Budget(ctx, project)
+	if err != nil {
+		return err
+	}
+	exceeded := func(code int, spent, limit float64) error {
+		return errors.NewBusinessError(code).WithParam("project", project).
+			WithParam("spent", fmt.Sprintf("%.2f %s", spent, status.Currency)).
+			WithParam("limit", fmt.Sprintf("%.2f %s", limit, status.Currency))
+	}
+	if status.Daily > 0 && status.DailySpent >= status.Daily {
+		return exceeded(errors.DailyBudgetExceeded, status.DailySpent, status.Daily)
+	}
+	if status.Monthly > 0 && status.MonthlySpent >= status.Monthly {
+		return exceeded(errors.MonthlyBudgetExceeded, status.MonthlySpent, status.Monthly)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"civ/config/autoload"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	analyzer "civ/proto/analyzer/v1"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestUsageService(db *gorm.DB, now *time.Time, cfg autoload.UsageConfig) *usageServiceImpl {
+	return &usageServiceImpl{
+		db:     db,
+		now:    func() time.Time { return *now },
+		config: func() autoload.UsageConfig { return cfg },
+	}
+}
+
+func TestRecordUsageAndReport(t *testing.T) {
+	db := useTestDB(t)
+	ctx := context.Background()
+	now := time.Date(2026, 9, 30, 12, 0, 0, 0, time.Local)
+	usage := newTestUsageService(db, &now, autoload.UsageConfig{
+		Currency: "USD",
+		Prices:   map[string]autoload.ModelPrice{"deepseek-chat": {Prompt: 0.5, Completion: 2}},
+	})
+
+	alice, bob := uint(1), uint(2)
+	record := func(project string, user *uint, u *analyzer.Usage) *model.AnalysisUsage {
+		t.Helper()
+		analysis := &model.Analysis{Status: model.AnalysisSucceeded, Model: "deepseek", RequestedBy: user}
+		require.NoError(t, db.Create(analysis).Error)
+		got, err := usage.Record(ctx, &model.Job{Project: project}, analysis, u)
+		require.NoError(t, err)
+		return got
+	}
+
+	got := record("civ", &alice, &analyzer.Usage{Model: "deepseek-chat", PromptTokens: 1_000_000, CompletionTokens: 500_000, LatencyMs: 1200})
+	assert.InDelta(t, 1.5, got.Cost, 1e-9)
+	assert.Equal(t, "2026-09-30", got.Day)
+	assert.Equal(t, "2026-09", got.Month)
+	got = record("civ", &bob, &analyzer.Usage{PromptTokens: 2000})
+	assert.Equal(t, "deepseek", got.Model, "falls back to the requested model")
+	assert.Zero(t, got.Cost, "models without a price cost nothing")
+	assert.Nil(t, record("civ", &bob, nil))
+	now = now.AddDate(0, 0, 1)
+	record("other", &alice, &analyzer.Usage{Model: "deepseek-chat", PromptTokens: 2_000_000})
+
+	daily, err := usage.Report(ctx, UsageQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []UsageTotal{
+		{Period: "2026-09-30", Project: "civ", Analyses: 2, PromptTokens: 1_002_000, CompletionTokens: 500_000, Cost: 1.5},
+		{Period: "2026-10-01", Project: "other", Analyses: 1, PromptTokens: 2_000_000, Cost: 1},
+	}, daily)
+
+	monthly, err := usage.Report(ctx, UsageQuery{Period: UsageMonthly, GroupBy: UsageByUser, Project: "civ"})
+	require.NoError(t, err)
+	assert.Equal(t, []UsageTotal{
+		{Period: "2026-09", UserID: &alice, Analyses: 1, PromptTokens: 1_000_000, CompletionTokens: 500_000, Cost: 1.5},
+		{Period: "2026-09", UserID: &bob, Analyses: 1, PromptTokens: 2000},
+	}, monthly)
+
+	ranged, err := usage.Report(ctx, UsageQuery{From: "2026-10-01", To: "2026-10-31"})
+	require.NoError(t, err)
+	require.Len(t, ranged, 1)
+	assert.Equal(t, "other", ranged[0].Project)
+}
+
+func TestBudgetBlocksAnalyses(t *testing.T) {
+	db := useTestDB(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	usage := newTestUsageService(db, &now, autoload.UsageConfig{
+		Currency: "USD",
+		Budgets: []autoload.BudgetConfig{
+			{Daily: 1},
+			{Project: "civ", Daily: 5, Monthly: 8},
+		},
+	})
+	require.NoError(t, db.Create(&[]model.AnalysisUsage{
+		{AnalysisID: 1, Project: "civ", Model: "m", Cost: 4, Day: "2026-10-18", Month: "2026-10"},
+		{AnalysisID: 2, Project: "civ", Model: "m", Cost: 3, Day: "2026-10-19", Month: "2026-10"},
+		{AnalysisID: 3, Project: "other", Model: "m", Cost: 1, Day: "2026-10-19", Month: "2026-10"},
+	}).Error)
+
+	status, err := usage.Budget(ctx, "civ")
+	require.NoError(t, err)
+	assert.Equal(t, &BudgetStatus{Project: "civ", Currency: "USD", Daily: 5, DailySpent: 3, Monthly: 8, MonthlySpent: 7}, status)
+	require.NoError(t, usage.CheckBudget(ctx, "civ"))
+
+	require.NoError(t, db.Create(&model.AnalysisUsage{AnalysisID: 4, Project: "civ", Model: "m", Cost: 1, Day: "2026-10-19", Month: "2026-10"}).Error)
+	requireCode(t, usage.CheckBudget(ctx, "civ"), errors.MonthlyBudgetExceeded)
+	requireCode(t, usage.CheckBudget(ctx, "other"), errors.DailyBudgetExceeded, "the default budget applies to projects without their own")
+
+	now = now.AddDate(0, 0, 1)
+	require.NoError(t, usage.CheckBudget(ctx, "other"))
+}
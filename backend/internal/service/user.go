@@ -0,0 +1,71 @@
+package service
+
+import (
+	"civ/data"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type UserService interface {
+	Create(ctx context.Context, username, email, password, role string) (*model.User, error)
+	GetByUsername(ctx context.Context, username string) (*model.User, error)
+}
+
+type userServiceImpl struct {
+	db *gorm.DB
+}
+
+func NewUserService() UserService {
+	return &userServiceImpl{db: data.DB}
+}
+
+func (s *userServiceImpl) Create(ctx context.Context, username, email, password, role string) (*model.User, error) {
+	if username == "" || password == "" {
+		return nil, errors.NewBusinessError(errors.InvalidParameter)
+	}
+	if role == "" {
+		role = model.RoleMember
+	}
+	if role != model.RoleAdmin && role != model.RoleMember {
+		return nil, errors.NewBusinessError(errors.InvalidParameter)
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&model.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.NewBusinessError(errors.UserAlreadyExists).WithParam("username", username)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &model.User{
+		Username:     username,
+		Email:        email,
+		PasswordHash: string(hash),
+		Role:         role,
+	}
+	if err := s.db.WithContext(ctx).Create(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userServiceImpl) GetByUsername(ctx context.Context, username string) (*model.User, error) {
+	var user model.User
+	err := s.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.NewBusinessError(errors.UserDoesNotExist).WithParam("username", username)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
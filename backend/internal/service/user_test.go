@@ -0,0 +1,53 @@
+package service
+
+import (
+	"civ/data/datatest"
+	"civ/internal/model"
+	"civ/internal/pkg/errors"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// useTestDB points data.DB at a migrated in-memory SQLite database for the test.
+func useTestDB(t *testing.T) *gorm.DB {
+	return datatest.Use(t)
+}
+
+func TestCreateUser(t *testing.T) {
+	useTestDB(t)
+	ctx := context.Background()
+
+	user, err := NewUserService().Create(ctx, "alice", "alice@example.com", "pa55word", "")
+	require.NoError(t, err)
+	assert.Equal(t, model.RoleMember, user.Role)
+	assert.NotEqual(t, "pa55word", user.PasswordHash)
+
+	_, err = NewUserService().Create(ctx, "alice", "", "other", model.RoleAdmin)
+	var businessError *errors.BusinessError
+	require.ErrorAs(t, err, &businessError)
+	assert.Equal(t, errors.UserAlreadyExists, businessError.GetCode())
+}
+
+func TestIssueToken(t *testing.T) {
+	db := useTestDB(t)
+	ctx := context.Background()
+	_, err := NewUserService().Create(ctx, "bob", "", "secret", model.RoleAdmin)
+	require.NoError(t, err)
+
+	plain, token, err := NewTokenService().Issue(ctx, "bob", "ci", time.Hour)
+	require.NoError(t, err)
+	assert.Contains(t, plain, TokenPrefix)
+	assert.NotNil(t, token.ExpiresAt)
+
+	var stored model.APIToken
+	require.NoError(t, db.First(&stored, token.ID).Error)
+	assert.Equal(t, HashToken(plain), stored.TokenHash, "only the hash is stored")
+
+	_, _, err = NewTokenService().Issue(ctx, "nobody", "ci", 0)
+	assert.Error(t, err)
+}
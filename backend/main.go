@@ -1,10 +1,12 @@
 package main
 
 import (
-	"civ/cmd/server"
+	"civ/cmd/cli"
+	"os"
 )
 
-// main is the program entry point. It delegates initialization and execution of the application server to server.RunServer().
+// main is the program entry point. It delegates to the subcommand named by the
+// first argument (see cli.Run); without one the application server is started.
 func main() {
-	server.RunServer()
+	os.Exit(cli.Run(os.Args[1:]))
 }
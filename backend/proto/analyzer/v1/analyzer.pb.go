@@ -0,0 +1,967 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: analyzer/v1/analyzer.proto
+
+package analyzerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CI 任务信息
+type Job struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Project   string                 `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Branch    string                 `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	CommitSha string                 `protobuf:"bytes,5,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	Status    string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// Unix 秒，未开始或未结束时为 0
+	StartedAt     int64 `protobuf:"varint,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64 `protobuf:"varint,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Job) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Job) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *Job) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Job) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *Job) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+func (x *Job) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Job) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Job) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+// 分析请求
+type AnalyzeRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AnalysisId int64                  `protobuf:"varint,1,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	Job        *Job                   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	// 任务日志，过长时只保留末尾部分
+	Log string `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	// 使用的模型，如 deepseek
+	Model string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	// 调用 Tools 服务的令牌，见 AnalyzeStart.tool_token
+	ToolToken string `protobuf:"bytes,5,opt,name=tool_token,json=toolToken,proto3" json:"tool_token,omitempty"`
+	// 后端渲染好的提示词，见 AnalyzeStart.prompt
+	Prompt        *Prompt `protobuf:"bytes,6,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeRequest) Reset() {
+	*x = AnalyzeRequest{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeRequest) ProtoMessage() {}
+
+func (x *AnalyzeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AnalyzeRequest) GetAnalysisId() int64 {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return 0
+}
+
+func (x *AnalyzeRequest) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *AnalyzeRequest) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetToolToken() string {
+	if x != nil {
+		return x.ToolToken
+	}
+	return ""
+}
+
+func (x *AnalyzeRequest) GetPrompt() *Prompt {
+	if x != nil {
+		return x.Prompt
+	}
+	return nil
+}
+
+// 分析结果
+type AnalyzeReply struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Summary    string                 `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	RootCause  string                 `protobuf:"bytes,2,opt,name=root_cause,json=rootCause,proto3" json:"root_cause,omitempty"`
+	Suggestion string                 `protobuf:"bytes,3,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	// 失败分类，如 compile、test、infra、dependency
+	Category string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// 本次分析的模型用量，后端据此计费
+	Usage         *Usage `protobuf:"bytes,5,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeReply) Reset() {
+	*x = AnalyzeReply{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeReply) ProtoMessage() {}
+
+func (x *AnalyzeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeReply.ProtoReflect.Descriptor instead.
+func (*AnalyzeReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AnalyzeReply) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *AnalyzeReply) GetRootCause() string {
+	if x != nil {
+		return x.RootCause
+	}
+	return ""
+}
+
+func (x *AnalyzeReply) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+func (x *AnalyzeReply) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AnalyzeReply) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// 模型用量，一次分析多次调用模型时为累计值
+type Usage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 实际调用的模型名，如 deepseek-chat
+	Model            string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	PromptTokens     int64  `protobuf:"varint,2,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	CompletionTokens int64  `protobuf:"varint,3,opt,name=completion_tokens,json=completionTokens,proto3" json:"completion_tokens,omitempty"`
+	// 调用模型的累计耗时
+	LatencyMs     int64 `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Usage) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Usage) GetPromptTokens() int64 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetCompletionTokens() int64 {
+	if x != nil {
+		return x.CompletionTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+// 流式分析中后端发送的消息
+type AnalyzeStreamRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*AnalyzeStreamRequest_Start
+	//	*AnalyzeStreamRequest_Chunk
+	Payload       isAnalyzeStreamRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeStreamRequest) Reset() {
+	*x = AnalyzeStreamRequest{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeStreamRequest) ProtoMessage() {}
+
+func (x *AnalyzeStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeStreamRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeStreamRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AnalyzeStreamRequest) GetPayload() isAnalyzeStreamRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AnalyzeStreamRequest) GetStart() *AnalyzeStart {
+	if x != nil {
+		if x, ok := x.Payload.(*AnalyzeStreamRequest_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+func (x *AnalyzeStreamRequest) GetChunk() *LogChunk {
+	if x != nil {
+		if x, ok := x.Payload.(*AnalyzeStreamRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isAnalyzeStreamRequest_Payload interface {
+	isAnalyzeStreamRequest_Payload()
+}
+
+type AnalyzeStreamRequest_Start struct {
+	Start *AnalyzeStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type AnalyzeStreamRequest_Chunk struct {
+	Chunk *LogChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*AnalyzeStreamRequest_Start) isAnalyzeStreamRequest_Payload() {}
+
+func (*AnalyzeStreamRequest_Chunk) isAnalyzeStreamRequest_Payload() {}
+
+// 流式分析的第一条消息
+type AnalyzeStart struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AnalysisId int64                  `protobuf:"varint,1,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	Job        *Job                   `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	Model      string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	// 日志总字节数，任务没有日志时为 0
+	LogSize int64 `protobuf:"varint,4,opt,name=log_size,json=logSize,proto3" json:"log_size,omitempty"`
+	// 调用后端 Tools 服务的令牌，放在 metadata x-civ-tool-token 中；只在本次分析进行中有效，
+	// 只能访问被分析任务所在项目的数据。后端未开启 gRPC 服务时为空
+	ToolToken string `protobuf:"bytes,5,opt,name=tool_token,json=toolToken,proto3" json:"tool_token,omitempty"`
+	// 后端渲染好的提示词（/api/prompts 中模板的当前版本），未配置模板时为空，代理使用内置提示词
+	Prompt        *Prompt `protobuf:"bytes,6,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeStart) Reset() {
+	*x = AnalyzeStart{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeStart) ProtoMessage() {}
+
+func (x *AnalyzeStart) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeStart.ProtoReflect.Descriptor instead.
+func (*AnalyzeStart) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AnalyzeStart) GetAnalysisId() int64 {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return 0
+}
+
+func (x *AnalyzeStart) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *AnalyzeStart) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *AnalyzeStart) GetLogSize() int64 {
+	if x != nil {
+		return x.LogSize
+	}
+	return 0
+}
+
+func (x *AnalyzeStart) GetToolToken() string {
+	if x != nil {
+		return x.ToolToken
+	}
+	return ""
+}
+
+func (x *AnalyzeStart) GetPrompt() *Prompt {
+	if x != nil {
+		return x.Prompt
+	}
+	return nil
+}
+
+// 提示词模板渲染结果
+type Prompt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Prompt) Reset() {
+	*x = Prompt{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prompt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prompt) ProtoMessage() {}
+
+func (x *Prompt) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prompt.ProtoReflect.Descriptor instead.
+func (*Prompt) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Prompt) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Prompt) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Prompt) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// 日志分片，一次 LogRequest 可能被拆成多个分片
+type LogChunk struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data   []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// 是否为本次 LogRequest 的最后一个分片
+	Last          bool `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogChunk) Reset() {
+	*x = LogChunk{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogChunk) ProtoMessage() {}
+
+func (x *LogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogChunk.ProtoReflect.Descriptor instead.
+func (*LogChunk) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LogChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *LogChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *LogChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+// 流式分析中代理发送的消息
+type AnalyzeStreamReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*AnalyzeStreamReply_LogRequest
+	//	*AnalyzeStreamReply_Result
+	//	*AnalyzeStreamReply_Usage
+	Payload       isAnalyzeStreamReply_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeStreamReply) Reset() {
+	*x = AnalyzeStreamReply{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeStreamReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeStreamReply) ProtoMessage() {}
+
+func (x *AnalyzeStreamReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeStreamReply.ProtoReflect.Descriptor instead.
+func (*AnalyzeStreamReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AnalyzeStreamReply) GetPayload() isAnalyzeStreamReply_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *AnalyzeStreamReply) GetLogRequest() *LogRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*AnalyzeStreamReply_LogRequest); ok {
+			return x.LogRequest
+		}
+	}
+	return nil
+}
+
+func (x *AnalyzeStreamReply) GetResult() *AnalyzeReply {
+	if x != nil {
+		if x, ok := x.Payload.(*AnalyzeStreamReply_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *AnalyzeStreamReply) GetUsage() *Usage {
+	if x != nil {
+		if x, ok := x.Payload.(*AnalyzeStreamReply_Usage); ok {
+			return x.Usage
+		}
+	}
+	return nil
+}
+
+type isAnalyzeStreamReply_Payload interface {
+	isAnalyzeStreamReply_Payload()
+}
+
+type AnalyzeStreamReply_LogRequest struct {
+	LogRequest *LogRequest `protobuf:"bytes,1,opt,name=log_request,json=logRequest,proto3,oneof"`
+}
+
+type AnalyzeStreamReply_Result struct {
+	Result *AnalyzeReply `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+type AnalyzeStreamReply_Usage struct {
+	// 截至目前的累计用量，代理每次调用模型后发送。分析失败或被取消时后端按最后一次收到的用量记录
+	Usage *Usage `protobuf:"bytes,3,opt,name=usage,proto3,oneof"`
+}
+
+func (*AnalyzeStreamReply_LogRequest) isAnalyzeStreamReply_Payload() {}
+
+func (*AnalyzeStreamReply_Result) isAnalyzeStreamReply_Payload() {}
+
+func (*AnalyzeStreamReply_Usage) isAnalyzeStreamReply_Payload() {}
+
+// 代理请求日志中 [offset, offset+length) 的内容，超出日志末尾的部分被截断；offset 超出日志大小的请求无效，后端结束本次分析
+type LogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length        int64                  `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogRequest) Reset() {
+	*x = LogRequest{}
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRequest) ProtoMessage() {}
+
+func (x *LogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_analyzer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
+func (*LogRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_analyzer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LogRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *LogRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+var File_analyzer_v1_analyzer_proto protoreflect.FileDescriptor
+
+const file_analyzer_v1_analyzer_proto_rawDesc = "" +
+	"\n" +
+	"\x1aanalyzer/v1/analyzer.proto\x12\x0fciv.analyzer.v1\"\xd2\x01\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aproject\x18\x02 \x01(\tR\aproject\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06branch\x18\x04 \x01(\tR\x06branch\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x05 \x01(\tR\tcommitSha\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\x03R\n" +
+	"finishedAt\"\xd1\x01\n" +
+	"\x0eAnalyzeRequest\x12\x1f\n" +
+	"\vanalysis_id\x18\x01 \x01(\x03R\n" +
+	"analysisId\x12&\n" +
+	"\x03job\x18\x02 \x01(\v2\x14.civ.analyzer.v1.JobR\x03job\x12\x10\n" +
+	"\x03log\x18\x03 \x01(\tR\x03log\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x1d\n" +
+	"\n" +
+	"tool_token\x18\x05 \x01(\tR\ttoolToken\x12/\n" +
+	"\x06prompt\x18\x06 \x01(\v2\x17.civ.analyzer.v1.PromptR\x06prompt\"\xb1\x01\n" +
+	"\fAnalyzeReply\x12\x18\n" +
+	"\asummary\x18\x01 \x01(\tR\asummary\x12\x1d\n" +
+	"\n" +
+	"root_cause\x18\x02 \x01(\tR\trootCause\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x03 \x01(\tR\n" +
+	"suggestion\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12,\n" +
+	"\x05usage\x18\x05 \x01(\v2\x16.civ.analyzer.v1.UsageR\x05usage\"\x8e\x01\n" +
+	"\x05Usage\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12#\n" +
+	"\rprompt_tokens\x18\x02 \x01(\x03R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x03 \x01(\x03R\x10completionTokens\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\"\x8b\x01\n" +
+	"\x14AnalyzeStreamRequest\x125\n" +
+	"\x05start\x18\x01 \x01(\v2\x1d.civ.analyzer.v1.AnalyzeStartH\x00R\x05start\x121\n" +
+	"\x05chunk\x18\x02 \x01(\v2\x19.civ.analyzer.v1.LogChunkH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xd8\x01\n" +
+	"\fAnalyzeStart\x12\x1f\n" +
+	"\vanalysis_id\x18\x01 \x01(\x03R\n" +
+	"analysisId\x12&\n" +
+	"\x03job\x18\x02 \x01(\v2\x14.civ.analyzer.v1.JobR\x03job\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x19\n" +
+	"\blog_size\x18\x04 \x01(\x03R\alogSize\x12\x1d\n" +
+	"\n" +
+	"tool_token\x18\x05 \x01(\tR\ttoolToken\x12/\n" +
+	"\x06prompt\x18\x06 \x01(\v2\x17.civ.analyzer.v1.PromptR\x06prompt\"J\n" +
+	"\x06Prompt\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"J\n" +
+	"\bLogChunk\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04last\x18\x03 \x01(\bR\x04last\"\xc8\x01\n" +
+	"\x12AnalyzeStreamReply\x12>\n" +
+	"\vlog_request\x18\x01 \x01(\v2\x1b.civ.analyzer.v1.LogRequestH\x00R\n" +
+	"logRequest\x127\n" +
+	"\x06result\x18\x02 \x01(\v2\x1d.civ.analyzer.v1.AnalyzeReplyH\x00R\x06result\x12.\n" +
+	"\x05usage\x18\x03 \x01(\v2\x16.civ.analyzer.v1.UsageH\x00R\x05usageB\t\n" +
+	"\apayload\"<\n" +
+	"\n" +
+	"LogRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\x03R\x06length2\xba\x01\n" +
+	"\bAnalyzer\x12K\n" +
+	"\aAnalyze\x12\x1f.civ.analyzer.v1.AnalyzeRequest\x1a\x1d.civ.analyzer.v1.AnalyzeReply\"\x00\x12a\n" +
+	"\rAnalyzeStream\x12%.civ.analyzer.v1.AnalyzeStreamRequest\x1a#.civ.analyzer.v1.AnalyzeStreamReply\"\x00(\x010\x01B\"Z civ/proto/analyzer/v1;analyzerv1b\x06proto3"
+
+var (
+	file_analyzer_v1_analyzer_proto_rawDescOnce sync.Once
+	file_analyzer_v1_analyzer_proto_rawDescData []byte
+)
+
+func file_analyzer_v1_analyzer_proto_rawDescGZIP() []byte {
+	file_analyzer_v1_analyzer_proto_rawDescOnce.Do(func() {
+		file_analyzer_v1_analyzer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analyzer_v1_analyzer_proto_rawDesc), len(file_analyzer_v1_analyzer_proto_rawDesc)))
+	})
+	return file_analyzer_v1_analyzer_proto_rawDescData
+}
+
+var file_analyzer_v1_analyzer_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_analyzer_v1_analyzer_proto_goTypes = []any{
+	(*Job)(nil),                  // 0: civ.analyzer.v1.Job
+	(*AnalyzeRequest)(nil),       // 1: civ.analyzer.v1.AnalyzeRequest
+	(*AnalyzeReply)(nil),         // 2: civ.analyzer.v1.AnalyzeReply
+	(*Usage)(nil),                // 3: civ.analyzer.v1.Usage
+	(*AnalyzeStreamRequest)(nil), // 4: civ.analyzer.v1.AnalyzeStreamRequest
+	(*AnalyzeStart)(nil),         // 5: civ.analyzer.v1.AnalyzeStart
+	(*Prompt)(nil),               // 6: civ.analyzer.v1.Prompt
+	(*LogChunk)(nil),             // 7: civ.analyzer.v1.LogChunk
+	(*AnalyzeStreamReply)(nil),   // 8: civ.analyzer.v1.AnalyzeStreamReply
+	(*LogRequest)(nil),           // 9: civ.analyzer.v1.LogRequest
+}
+var file_analyzer_v1_analyzer_proto_depIdxs = []int32{
+	0,  // 0: civ.analyzer.v1.AnalyzeRequest.job:type_name -> civ.analyzer.v1.Job
+	6,  // 1: civ.analyzer.v1.AnalyzeRequest.prompt:type_name -> civ.analyzer.v1.Prompt
+	3,  // 2: civ.analyzer.v1.AnalyzeReply.usage:type_name -> civ.analyzer.v1.Usage
+	5,  // 3: civ.analyzer.v1.AnalyzeStreamRequest.start:type_name -> civ.analyzer.v1.AnalyzeStart
+	7,  // 4: civ.analyzer.v1.AnalyzeStreamRequest.chunk:type_name -> civ.analyzer.v1.LogChunk
+	0,  // 5: civ.analyzer.v1.AnalyzeStart.job:type_name -> civ.analyzer.v1.Job
+	6,  // 6: civ.analyzer.v1.AnalyzeStart.prompt:type_name -> civ.analyzer.v1.Prompt
+	9,  // 7: civ.analyzer.v1.AnalyzeStreamReply.log_request:type_name -> civ.analyzer.v1.LogRequest
+	2,  // 8: civ.analyzer.v1.AnalyzeStreamReply.result:type_name -> civ.analyzer.v1.AnalyzeReply
+	3,  // 9: civ.analyzer.v1.AnalyzeStreamReply.usage:type_name -> civ.analyzer.v1.Usage
+	1,  // 10: civ.analyzer.v1.Analyzer.Analyze:input_type -> civ.analyzer.v1.AnalyzeRequest
+	4,  // 11: civ.analyzer.v1.Analyzer.AnalyzeStream:input_type -> civ.analyzer.v1.AnalyzeStreamRequest
+	2,  // 12: civ.analyzer.v1.Analyzer.Analyze:output_type -> civ.analyzer.v1.AnalyzeReply
+	8,  // 13: civ.analyzer.v1.Analyzer.AnalyzeStream:output_type -> civ.analyzer.v1.AnalyzeStreamReply
+	12, // [12:14] is the sub-list for method output_type
+	10, // [10:12] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_analyzer_v1_analyzer_proto_init() }
+func file_analyzer_v1_analyzer_proto_init() {
+	if File_analyzer_v1_analyzer_proto != nil {
+		return
+	}
+	file_analyzer_v1_analyzer_proto_msgTypes[4].OneofWrappers = []any{
+		(*AnalyzeStreamRequest_Start)(nil),
+		(*AnalyzeStreamRequest_Chunk)(nil),
+	}
+	file_analyzer_v1_analyzer_proto_msgTypes[8].OneofWrappers = []any{
+		(*AnalyzeStreamReply_LogRequest)(nil),
+		(*AnalyzeStreamReply_Result)(nil),
+		(*AnalyzeStreamReply_Usage)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analyzer_v1_analyzer_proto_rawDesc), len(file_analyzer_v1_analyzer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analyzer_v1_analyzer_proto_goTypes,
+		DependencyIndexes: file_analyzer_v1_analyzer_proto_depIdxs,
+		MessageInfos:      file_analyzer_v1_analyzer_proto_msgTypes,
+	}.Build()
+	File_analyzer_v1_analyzer_proto = out.File
+	file_analyzer_v1_analyzer_proto_goTypes = nil
+	file_analyzer_v1_analyzer_proto_depIdxs = nil
+}
@@ -0,0 +1,35 @@
+package analyzerv1
+
+import (
+	"civ/internal/pkg/protocompat"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// TestCompatibleWithBaseline 将当前 civ.analyzer.v1 的描述符与已发布的基线比较，
+// 不兼容的修改应放到 civ.analyzer.v2。发布后用以下命令在项目根目录更新基线：
+//
+//	protoc -I proto --descriptor_set_out=backend/proto/analyzer/v1/testdata/baseline.binpb analyzer/v1/analyzer.proto analyzer/v1/registry.proto analyzer/v1/tools.proto
+func TestCompatibleWithBaseline(t *testing.T) {
+	raw, err := os.ReadFile("testdata/baseline.binpb")
+	require.NoError(t, err)
+	baseline := &descriptorpb.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(raw, baseline))
+
+	current := &descriptorpb.FileDescriptorSet{}
+	protoregistry.GlobalFiles.RangeFilesByPackage("civ.analyzer.v1", func(fd protoreflect.FileDescriptor) bool {
+		current.File = append(current.File, protodesc.ToFileDescriptorProto(fd))
+		return true
+	})
+	require.NotEmpty(t, current.File)
+
+	assert.Empty(t, protocompat.Check(baseline, current), "wire-incompatible changes to civ.analyzer.v1")
+}
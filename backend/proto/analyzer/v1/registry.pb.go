@@ -0,0 +1,421 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: analyzer/v1/registry.proto
+
+package analyzerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 代理支持的模型
+type ModelInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 模型名，与 AnalyzeRequest.model 对应，如 deepseek
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 最大上下文长度（token 数）
+	MaxContextTokens int32 `protobuf:"varint,2,opt,name=max_context_tokens,json=maxContextTokens,proto3" json:"max_context_tokens,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ModelInfo) Reset() {
+	*x = ModelInfo{}
+	mi := &file_analyzer_v1_registry_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelInfo) ProtoMessage() {}
+
+func (x *ModelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_registry_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelInfo.ProtoReflect.Descriptor instead.
+func (*ModelInfo) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_registry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ModelInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetMaxContextTokens() int32 {
+	if x != nil {
+		return x.MaxContextTokens
+	}
+	return 0
+}
+
+// 代理上报的能力
+type AgentInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 代理实例 ID，同一实例重复注册时保持不变
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 后端回连代理的 gRPC 地址，host:port
+	Address string       `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Version string       `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Models  []*ModelInfo `protobuf:"bytes,4,rep,name=models,proto3" json:"models,omitempty"`
+	// 支持的分析类型，如 build_failure、flaky_test
+	AnalysisTypes []string `protobuf:"bytes,5,rep,name=analysis_types,json=analysisTypes,proto3" json:"analysis_types,omitempty"`
+	// 代理可调用的工具
+	Tools         []string `protobuf:"bytes,6,rep,name=tools,proto3" json:"tools,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentInfo) Reset() {
+	*x = AgentInfo{}
+	mi := &file_analyzer_v1_registry_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentInfo) ProtoMessage() {}
+
+func (x *AgentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_registry_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentInfo.ProtoReflect.Descriptor instead.
+func (*AgentInfo) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_registry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AgentInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentInfo) GetModels() []*ModelInfo {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetAnalysisTypes() []string {
+	if x != nil {
+		return x.AnalysisTypes
+	}
+	return nil
+}
+
+func (x *AgentInfo) GetTools() []string {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+type RegisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *AgentInfo             `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_analyzer_v1_registry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_registry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_registry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RegisterRequest) GetAgent() *AgentInfo {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type RegisterReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 代理再次调用 Register 的间隔
+	HeartbeatIntervalSeconds int32 `protobuf:"varint,1,opt,name=heartbeat_interval_seconds,json=heartbeatIntervalSeconds,proto3" json:"heartbeat_interval_seconds,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *RegisterReply) Reset() {
+	*x = RegisterReply{}
+	mi := &file_analyzer_v1_registry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterReply) ProtoMessage() {}
+
+func (x *RegisterReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_registry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterReply.ProtoReflect.Descriptor instead.
+func (*RegisterReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_registry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterReply) GetHeartbeatIntervalSeconds() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSeconds
+	}
+	return 0
+}
+
+type DeregisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterRequest) Reset() {
+	*x = DeregisterRequest{}
+	mi := &file_analyzer_v1_registry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterRequest) ProtoMessage() {}
+
+func (x *DeregisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_registry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_registry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeregisterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeregisterReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterReply) Reset() {
+	*x = DeregisterReply{}
+	mi := &file_analyzer_v1_registry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterReply) ProtoMessage() {}
+
+func (x *DeregisterReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_registry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterReply.ProtoReflect.Descriptor instead.
+func (*DeregisterReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_registry_proto_rawDescGZIP(), []int{5}
+}
+
+var File_analyzer_v1_registry_proto protoreflect.FileDescriptor
+
+const file_analyzer_v1_registry_proto_rawDesc = "" +
+	"\n" +
+	"\x1aanalyzer/v1/registry.proto\x12\x0fciv.analyzer.v1\"M\n" +
+	"\tModelInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x12max_context_tokens\x18\x02 \x01(\x05R\x10maxContextTokens\"\xc0\x01\n" +
+	"\tAgentInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x122\n" +
+	"\x06models\x18\x04 \x03(\v2\x1a.civ.analyzer.v1.ModelInfoR\x06models\x12%\n" +
+	"\x0eanalysis_types\x18\x05 \x03(\tR\ranalysisTypes\x12\x14\n" +
+	"\x05tools\x18\x06 \x03(\tR\x05tools\"C\n" +
+	"\x0fRegisterRequest\x120\n" +
+	"\x05agent\x18\x01 \x01(\v2\x1a.civ.analyzer.v1.AgentInfoR\x05agent\"M\n" +
+	"\rRegisterReply\x12<\n" +
+	"\x1aheartbeat_interval_seconds\x18\x01 \x01(\x05R\x18heartbeatIntervalSeconds\"#\n" +
+	"\x11DeregisterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x11\n" +
+	"\x0fDeregisterReply2\xb0\x01\n" +
+	"\bRegistry\x12N\n" +
+	"\bRegister\x12 .civ.analyzer.v1.RegisterRequest\x1a\x1e.civ.analyzer.v1.RegisterReply\"\x00\x12T\n" +
+	"\n" +
+	"Deregister\x12\".civ.analyzer.v1.DeregisterRequest\x1a .civ.analyzer.v1.DeregisterReply\"\x00B\"Z civ/proto/analyzer/v1;analyzerv1b\x06proto3"
+
+var (
+	file_analyzer_v1_registry_proto_rawDescOnce sync.Once
+	file_analyzer_v1_registry_proto_rawDescData []byte
+)
+
+func file_analyzer_v1_registry_proto_rawDescGZIP() []byte {
+	file_analyzer_v1_registry_proto_rawDescOnce.Do(func() {
+		file_analyzer_v1_registry_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analyzer_v1_registry_proto_rawDesc), len(file_analyzer_v1_registry_proto_rawDesc)))
+	})
+	return file_analyzer_v1_registry_proto_rawDescData
+}
+
+var file_analyzer_v1_registry_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_analyzer_v1_registry_proto_goTypes = []any{
+	(*ModelInfo)(nil),         // 0: civ.analyzer.v1.ModelInfo
+	(*AgentInfo)(nil),         // 1: civ.analyzer.v1.AgentInfo
+	(*RegisterRequest)(nil),   // 2: civ.analyzer.v1.RegisterRequest
+	(*RegisterReply)(nil),     // 3: civ.analyzer.v1.RegisterReply
+	(*DeregisterRequest)(nil), // 4: civ.analyzer.v1.DeregisterRequest
+	(*DeregisterReply)(nil),   // 5: civ.analyzer.v1.DeregisterReply
+}
+var file_analyzer_v1_registry_proto_depIdxs = []int32{
+	0, // 0: civ.analyzer.v1.AgentInfo.models:type_name -> civ.analyzer.v1.ModelInfo
+	1, // 1: civ.analyzer.v1.RegisterRequest.agent:type_name -> civ.analyzer.v1.AgentInfo
+	2, // 2: civ.analyzer.v1.Registry.Register:input_type -> civ.analyzer.v1.RegisterRequest
+	4, // 3: civ.analyzer.v1.Registry.Deregister:input_type -> civ.analyzer.v1.DeregisterRequest
+	3, // 4: civ.analyzer.v1.Registry.Register:output_type -> civ.analyzer.v1.RegisterReply
+	5, // 5: civ.analyzer.v1.Registry.Deregister:output_type -> civ.analyzer.v1.DeregisterReply
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_analyzer_v1_registry_proto_init() }
+func file_analyzer_v1_registry_proto_init() {
+	if File_analyzer_v1_registry_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analyzer_v1_registry_proto_rawDesc), len(file_analyzer_v1_registry_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analyzer_v1_registry_proto_goTypes,
+		DependencyIndexes: file_analyzer_v1_registry_proto_depIdxs,
+		MessageInfos:      file_analyzer_v1_registry_proto_msgTypes,
+	}.Build()
+	File_analyzer_v1_registry_proto = out.File
+	file_analyzer_v1_registry_proto_goTypes = nil
+	file_analyzer_v1_registry_proto_depIdxs = nil
+}
@@ -0,0 +1,729 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.0
+// source: analyzer/v1/tools.proto
+
+package analyzerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetJobRequest) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type ListRecentRunsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 任务名，为空时取被分析任务的任务名
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 只返回该分支的运行，为空时不限
+	Branch string `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	// 默认 10，最多 50
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentRunsRequest) Reset() {
+	*x = ListRecentRunsRequest{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentRunsRequest) ProtoMessage() {}
+
+func (x *ListRecentRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentRunsRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRecentRunsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListRecentRunsRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *ListRecentRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRecentRunsReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 按开始时间倒序
+	Runs          []*Job `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentRunsReply) Reset() {
+	*x = ListRecentRunsReply{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentRunsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentRunsReply) ProtoMessage() {}
+
+func (x *ListRecentRunsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentRunsReply.ProtoReflect.Descriptor instead.
+func (*ListRecentRunsReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListRecentRunsReply) GetRuns() []*Job {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type GetLogRangeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 为 0 时读取被分析任务的日志
+	JobId  int64 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 最多 1 MiB
+	Length        int64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogRangeRequest) Reset() {
+	*x = GetLogRangeRequest{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogRangeRequest) ProtoMessage() {}
+
+func (x *GetLogRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogRangeRequest.ProtoReflect.Descriptor instead.
+func (*GetLogRangeRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLogRangeRequest) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *GetLogRangeRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetLogRangeRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type GetLogRangeReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	LogSize       int64                  `protobuf:"varint,3,opt,name=log_size,json=logSize,proto3" json:"log_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogRangeReply) Reset() {
+	*x = GetLogRangeReply{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogRangeReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogRangeReply) ProtoMessage() {}
+
+func (x *GetLogRangeReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogRangeReply.ProtoReflect.Descriptor instead.
+func (*GetLogRangeReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetLogRangeReply) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetLogRangeReply) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetLogRangeReply) GetLogSize() int64 {
+	if x != nil {
+		return x.LogSize
+	}
+	return 0
+}
+
+type GetCommitDiffRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 为空时取被分析任务的提交
+	CommitSha string `protobuf:"bytes,1,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	// 返回内容的上限，默认 256 KiB
+	MaxBytes      int32 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitDiffRequest) Reset() {
+	*x = GetCommitDiffRequest{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitDiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitDiffRequest) ProtoMessage() {}
+
+func (x *GetCommitDiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitDiffRequest.ProtoReflect.Descriptor instead.
+func (*GetCommitDiffRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCommitDiffRequest) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+func (x *GetCommitDiffRequest) GetMaxBytes() int32 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+type GetCommitDiffReply struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommitSha string                 `protobuf:"bytes,1,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	// git show 的输出，包含提交说明、改动统计和补丁
+	Diff string `protobuf:"bytes,2,opt,name=diff,proto3" json:"diff,omitempty"`
+	// 输出超过 max_bytes 被截断
+	Truncated     bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommitDiffReply) Reset() {
+	*x = GetCommitDiffReply{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommitDiffReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommitDiffReply) ProtoMessage() {}
+
+func (x *GetCommitDiffReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommitDiffReply.ProtoReflect.Descriptor instead.
+func (*GetCommitDiffReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCommitDiffReply) GetCommitSha() string {
+	if x != nil {
+		return x.CommitSha
+	}
+	return ""
+}
+
+func (x *GetCommitDiffReply) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *GetCommitDiffReply) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type SearchSimilarFailuresRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 在摘要和根因中匹配的关键字，空格分隔，全部命中才返回
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// 只返回该失败分类，为空时不限
+	Category string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	// 默认 5，最多 20
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSimilarFailuresRequest) Reset() {
+	*x = SearchSimilarFailuresRequest{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSimilarFailuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSimilarFailuresRequest) ProtoMessage() {}
+
+func (x *SearchSimilarFailuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSimilarFailuresRequest.ProtoReflect.Descriptor instead.
+func (*SearchSimilarFailuresRequest) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchSimilarFailuresRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSimilarFailuresRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchSimilarFailuresRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SimilarFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	AnalysisId    int64                  `protobuf:"varint,2,opt,name=analysis_id,json=analysisId,proto3" json:"analysis_id,omitempty"`
+	Summary       string                 `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	RootCause     string                 `protobuf:"bytes,4,opt,name=root_cause,json=rootCause,proto3" json:"root_cause,omitempty"`
+	Suggestion    string                 `protobuf:"bytes,5,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarFailure) Reset() {
+	*x = SimilarFailure{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarFailure) ProtoMessage() {}
+
+func (x *SimilarFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarFailure.ProtoReflect.Descriptor instead.
+func (*SimilarFailure) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SimilarFailure) GetJob() *Job {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *SimilarFailure) GetAnalysisId() int64 {
+	if x != nil {
+		return x.AnalysisId
+	}
+	return 0
+}
+
+func (x *SimilarFailure) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *SimilarFailure) GetRootCause() string {
+	if x != nil {
+		return x.RootCause
+	}
+	return ""
+}
+
+func (x *SimilarFailure) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+func (x *SimilarFailure) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type SearchSimilarFailuresReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Failures      []*SimilarFailure      `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSimilarFailuresReply) Reset() {
+	*x = SearchSimilarFailuresReply{}
+	mi := &file_analyzer_v1_tools_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSimilarFailuresReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSimilarFailuresReply) ProtoMessage() {}
+
+func (x *SearchSimilarFailuresReply) ProtoReflect() protoreflect.Message {
+	mi := &file_analyzer_v1_tools_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSimilarFailuresReply.ProtoReflect.Descriptor instead.
+func (*SearchSimilarFailuresReply) Descriptor() ([]byte, []int) {
+	return file_analyzer_v1_tools_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchSimilarFailuresReply) GetFailures() []*SimilarFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+var File_analyzer_v1_tools_proto protoreflect.FileDescriptor
+
+const file_analyzer_v1_tools_proto_rawDesc = "" +
+	"\n" +
+	"\x17analyzer/v1/tools.proto\x12\x0fciv.analyzer.v1\x1a\x1aanalyzer/v1/analyzer.proto\"&\n" +
+	"\rGetJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\"Y\n" +
+	"\x15ListRecentRunsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06branch\x18\x02 \x01(\tR\x06branch\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"?\n" +
+	"\x13ListRecentRunsReply\x12(\n" +
+	"\x04runs\x18\x01 \x03(\v2\x14.civ.analyzer.v1.JobR\x04runs\"[\n" +
+	"\x12GetLogRangeRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\x03R\x05jobId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"Y\n" +
+	"\x10GetLogRangeReply\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x19\n" +
+	"\blog_size\x18\x03 \x01(\x03R\alogSize\"R\n" +
+	"\x14GetCommitDiffRequest\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x01 \x01(\tR\tcommitSha\x12\x1b\n" +
+	"\tmax_bytes\x18\x02 \x01(\x05R\bmaxBytes\"e\n" +
+	"\x12GetCommitDiffReply\x12\x1d\n" +
+	"\n" +
+	"commit_sha\x18\x01 \x01(\tR\tcommitSha\x12\x12\n" +
+	"\x04diff\x18\x02 \x01(\tR\x04diff\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\"f\n" +
+	"\x1cSearchSimilarFailuresRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xce\x01\n" +
+	"\x0eSimilarFailure\x12&\n" +
+	"\x03job\x18\x01 \x01(\v2\x14.civ.analyzer.v1.JobR\x03job\x12\x1f\n" +
+	"\vanalysis_id\x18\x02 \x01(\x03R\n" +
+	"analysisId\x12\x18\n" +
+	"\asummary\x18\x03 \x01(\tR\asummary\x12\x1d\n" +
+	"\n" +
+	"root_cause\x18\x04 \x01(\tR\trootCause\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x05 \x01(\tR\n" +
+	"suggestion\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\"Y\n" +
+	"\x1aSearchSimilarFailuresReply\x12;\n" +
+	"\bfailures\x18\x01 \x03(\v2\x1f.civ.analyzer.v1.SimilarFailureR\bfailures2\xda\x03\n" +
+	"\x05Tools\x12@\n" +
+	"\x06GetJob\x12\x1e.civ.analyzer.v1.GetJobRequest\x1a\x14.civ.analyzer.v1.Job\"\x00\x12`\n" +
+	"\x0eListRecentRuns\x12&.civ.analyzer.v1.ListRecentRunsRequest\x1a$.civ.analyzer.v1.ListRecentRunsReply\"\x00\x12W\n" +
+	"\vGetLogRange\x12#.civ.analyzer.v1.GetLogRangeRequest\x1a!.civ.analyzer.v1.GetLogRangeReply\"\x00\x12]\n" +
+	"\rGetCommitDiff\x12%.civ.analyzer.v1.GetCommitDiffRequest\x1a#.civ.analyzer.v1.GetCommitDiffReply\"\x00\x12u\n" +
+	"\x15SearchSimilarFailures\x12-.civ.analyzer.v1.SearchSimilarFailuresRequest\x1a+.civ.analyzer.v1.SearchSimilarFailuresReply\"\x00B\"Z civ/proto/analyzer/v1;analyzerv1b\x06proto3"
+
+var (
+	file_analyzer_v1_tools_proto_rawDescOnce sync.Once
+	file_analyzer_v1_tools_proto_rawDescData []byte
+)
+
+func file_analyzer_v1_tools_proto_rawDescGZIP() []byte {
+	file_analyzer_v1_tools_proto_rawDescOnce.Do(func() {
+		file_analyzer_v1_tools_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_analyzer_v1_tools_proto_rawDesc), len(file_analyzer_v1_tools_proto_rawDesc)))
+	})
+	return file_analyzer_v1_tools_proto_rawDescData
+}
+
+var file_analyzer_v1_tools_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_analyzer_v1_tools_proto_goTypes = []any{
+	(*GetJobRequest)(nil),                // 0: civ.analyzer.v1.GetJobRequest
+	(*ListRecentRunsRequest)(nil),        // 1: civ.analyzer.v1.ListRecentRunsRequest
+	(*ListRecentRunsReply)(nil),          // 2: civ.analyzer.v1.ListRecentRunsReply
+	(*GetLogRangeRequest)(nil),           // 3: civ.analyzer.v1.GetLogRangeRequest
+	(*GetLogRangeReply)(nil),             // 4: civ.analyzer.v1.GetLogRangeReply
+	(*GetCommitDiffRequest)(nil),         // 5: civ.analyzer.v1.GetCommitDiffRequest
+	(*GetCommitDiffReply)(nil),           // 6: civ.analyzer.v1.GetCommitDiffReply
+	(*SearchSimilarFailuresRequest)(nil), // 7: civ.analyzer.v1.SearchSimilarFailuresRequest
+	(*SimilarFailure)(nil),               // 8: civ.analyzer.v1.SimilarFailure
+	(*SearchSimilarFailuresReply)(nil),   // 9: civ.analyzer.v1.SearchSimilarFailuresReply
+	(*Job)(nil),                          // 10: civ.analyzer.v1.Job
+}
+var file_analyzer_v1_tools_proto_depIdxs = []int32{
+	10, // 0: civ.analyzer.v1.ListRecentRunsReply.runs:type_name -> civ.analyzer.v1.Job
+	10, // 1: civ.analyzer.v1.SimilarFailure.job:type_name -> civ.analyzer.v1.Job
+	8,  // 2: civ.analyzer.v1.SearchSimilarFailuresReply.failures:type_name -> civ.analyzer.v1.SimilarFailure
+	0,  // 3: civ.analyzer.v1.Tools.GetJob:input_type -> civ.analyzer.v1.GetJobRequest
+	1,  // 4: civ.analyzer.v1.Tools.ListRecentRuns:input_type -> civ.analyzer.v1.ListRecentRunsRequest
+	3,  // 5: civ.analyzer.v1.Tools.GetLogRange:input_type -> civ.analyzer.v1.GetLogRangeRequest
+	5,  // 6: civ.analyzer.v1.Tools.GetCommitDiff:input_type -> civ.analyzer.v1.GetCommitDiffRequest
+	7,  // 7: civ.analyzer.v1.Tools.SearchSimilarFailures:input_type -> civ.analyzer.v1.SearchSimilarFailuresRequest
+	10, // 8: civ.analyzer.v1.Tools.GetJob:output_type -> civ.analyzer.v1.Job
+	2,  // 9: civ.analyzer.v1.Tools.ListRecentRuns:output_type -> civ.analyzer.v1.ListRecentRunsReply
+	4,  // 10: civ.analyzer.v1.Tools.GetLogRange:output_type -> civ.analyzer.v1.GetLogRangeReply
+	6,  // 11: civ.analyzer.v1.Tools.GetCommitDiff:output_type -> civ.analyzer.v1.GetCommitDiffReply
+	9,  // 12: civ.analyzer.v1.Tools.SearchSimilarFailures:output_type -> civ.analyzer.v1.SearchSimilarFailuresReply
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_analyzer_v1_tools_proto_init() }
+func file_analyzer_v1_tools_proto_init() {
+	if File_analyzer_v1_tools_proto != nil {
+		return
+	}
+	file_analyzer_v1_analyzer_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_analyzer_v1_tools_proto_rawDesc), len(file_analyzer_v1_tools_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_analyzer_v1_tools_proto_goTypes,
+		DependencyIndexes: file_analyzer_v1_tools_proto_depIdxs,
+		MessageInfos:      file_analyzer_v1_tools_proto_msgTypes,
+	}.Build()
+	File_analyzer_v1_tools_proto = out.File
+	file_analyzer_v1_tools_proto_goTypes = nil
+	file_analyzer_v1_tools_proto_depIdxs = nil
+}
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.0
+// source: analyzer/v1/tools.proto
+
+package analyzerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Tools_GetJob_FullMethodName                = "/civ.analyzer.v1.Tools/GetJob"
+	Tools_ListRecentRuns_FullMethodName        = "/civ.analyzer.v1.Tools/ListRecentRuns"
+	Tools_GetLogRange_FullMethodName           = "/civ.analyzer.v1.Tools/GetLogRange"
+	Tools_GetCommitDiff_FullMethodName         = "/civ.analyzer.v1.Tools/GetCommitDiff"
+	Tools_SearchSimilarFailures_FullMethodName = "/civ.analyzer.v1.Tools/SearchSimilarFailures"
+)
+
+// ToolsClient is the client API for Tools service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// 代理在分析过程中回调后端查询数据的只读工具，由后端实现。
+// 每次调用需在 metadata x-civ-tool-token 中携带 AnalyzeStart.tool_token，
+// 令牌只在对应的分析进行中有效，且只能访问被分析任务所在项目的数据
+type ToolsClient interface {
+	// 查询任务，job_id 为 0 时返回被分析的任务
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error)
+	// 同一任务最近的运行记录，用于判断是否为偶发失败
+	ListRecentRuns(ctx context.Context, in *ListRecentRunsRequest, opts ...grpc.CallOption) (*ListRecentRunsReply, error)
+	// 读取任务日志的一段
+	GetLogRange(ctx context.Context, in *GetLogRangeRequest, opts ...grpc.CallOption) (*GetLogRangeReply, error)
+	// 提交的说明与改动
+	GetCommitDiff(ctx context.Context, in *GetCommitDiffRequest, opts ...grpc.CallOption) (*GetCommitDiffReply, error)
+	// 同项目中已有分析结果的相似失败
+	SearchSimilarFailures(ctx context.Context, in *SearchSimilarFailuresRequest, opts ...grpc.CallOption) (*SearchSimilarFailuresReply, error)
+}
+
+type toolsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewToolsClient(cc grpc.ClientConnInterface) ToolsClient {
+	return &toolsClient{cc}
+}
+
+func (c *toolsClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Tools_GetJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolsClient) ListRecentRuns(ctx context.Context, in *ListRecentRunsRequest, opts ...grpc.CallOption) (*ListRecentRunsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecentRunsReply)
+	err := c.cc.Invoke(ctx, Tools_ListRecentRuns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolsClient) GetLogRange(ctx context.Context, in *GetLogRangeRequest, opts ...grpc.CallOption) (*GetLogRangeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLogRangeReply)
+	err := c.cc.Invoke(ctx, Tools_GetLogRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolsClient) GetCommitDiff(ctx context.Context, in *GetCommitDiffRequest, opts ...grpc.CallOption) (*GetCommitDiffReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCommitDiffReply)
+	err := c.cc.Invoke(ctx, Tools_GetCommitDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolsClient) SearchSimilarFailures(ctx context.Context, in *SearchSimilarFailuresRequest, opts ...grpc.CallOption) (*SearchSimilarFailuresReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSimilarFailuresReply)
+	err := c.cc.Invoke(ctx, Tools_SearchSimilarFailures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ToolsServer is the server API for Tools service.
+// All implementations must embed UnimplementedToolsServer
+// for forward compatibility.
+//
+// 代理在分析过程中回调后端查询数据的只读工具，由后端实现。
+// 每次调用需在 metadata x-civ-tool-token 中携带 AnalyzeStart.tool_token，
+// 令牌只在对应的分析进行中有效，且只能访问被分析任务所在项目的数据
+type ToolsServer interface {
+	// 查询任务，job_id 为 0 时返回被分析的任务
+	GetJob(context.Context, *GetJobRequest) (*Job, error)
+	// 同一任务最近的运行记录，用于判断是否为偶发失败
+	ListRecentRuns(context.Context, *ListRecentRunsRequest) (*ListRecentRunsReply, error)
+	// 读取任务日志的一段
+	GetLogRange(context.Context, *GetLogRangeRequest) (*GetLogRangeReply, error)
+	// 提交的说明与改动
+	GetCommitDiff(context.Context, *GetCommitDiffRequest) (*GetCommitDiffReply, error)
+	// 同项目中已有分析结果的相似失败
+	SearchSimilarFailures(context.Context, *SearchSimilarFailuresRequest) (*SearchSimilarFailuresReply, error)
+	mustEmbedUnimplementedToolsServer()
+}
+
+// UnimplementedToolsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedToolsServer struct{}
+
+func (UnimplementedToolsServer) GetJob(context.Context, *GetJobRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedToolsServer) ListRecentRuns(context.Context, *ListRecentRunsRequest) (*ListRecentRunsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentRuns not implemented")
+}
+func (UnimplementedToolsServer) GetLogRange(context.Context, *GetLogRangeRequest) (*GetLogRangeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogRange not implemented")
+}
+func (UnimplementedToolsServer) GetCommitDiff(context.Context, *GetCommitDiffRequest) (*GetCommitDiffReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommitDiff not implemented")
+}
+func (UnimplementedToolsServer) SearchSimilarFailures(context.Context, *SearchSimilarFailuresRequest) (*SearchSimilarFailuresReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSimilarFailures not implemented")
+}
+func (UnimplementedToolsServer) mustEmbedUnimplementedToolsServer() {}
+func (UnimplementedToolsServer) testEmbeddedByValue()               {}
+
+// UnsafeToolsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ToolsServer will
+// result in compilation errors.
+type UnsafeToolsServer interface {
+	mustEmbedUnimplementedToolsServer()
+}
+
+func RegisterToolsServer(s grpc.ServiceRegistrar, srv ToolsServer) {
+	// If the following call pancis, it indicates UnimplementedToolsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Tools_ServiceDesc, srv)
+}
+
+func _Tools_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolsServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tools_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolsServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tools_ListRecentRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolsServer).ListRecentRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tools_ListRecentRuns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolsServer).ListRecentRuns(ctx, req.(*ListRecentRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tools_GetLogRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolsServer).GetLogRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tools_GetLogRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolsServer).GetLogRange(ctx, req.(*GetLogRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tools_GetCommitDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommitDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolsServer).GetCommitDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tools_GetCommitDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolsServer).GetCommitDiff(ctx, req.(*GetCommitDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tools_SearchSimilarFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSimilarFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolsServer).SearchSimilarFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tools_SearchSimilarFailures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ToolsServer).SearchSimilarFailures(ctx, req.(*SearchSimilarFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Tools_ServiceDesc is the grpc.ServiceDesc for Tools service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tools_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "civ.analyzer.v1.Tools",
+	HandlerType: (*ToolsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetJob",
+			Handler:    _Tools_GetJob_Handler,
+		},
+		{
+			MethodName: "ListRecentRuns",
+			Handler:    _Tools_ListRecentRuns_Handler,
+		},
+		{
+			MethodName: "GetLogRange",
+			Handler:    _Tools_GetLogRange_Handler,
+		},
+		{
+			MethodName: "GetCommitDiff",
+			Handler:    _Tools_GetCommitDiff_Handler,
+		},
+		{
+			MethodName: "SearchSimilarFailures",
+			Handler:    _Tools_SearchSimilarFailures_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "analyzer/v1/tools.proto",
+}
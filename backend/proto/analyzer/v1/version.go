@@ -0,0 +1,11 @@
+package analyzerv1
+
+// 后端与代理在每次调用的 metadata 中携带各自实现的合约版本，
+// 对方不支持时以 FailedPrecondition 拒绝并在 trailer 中返回自己的版本。
+// 不兼容的修改须放到新的 proto 包（civ.analyzer.v2）中，并提升 APIVersion
+const (
+	// APIVersion 本包对应的合约版本
+	APIVersion = "v1"
+	// APIVersionKey metadata 中合约版本的键
+	APIVersionKey = "x-civ-api-version"
+)
@@ -1,8 +1,9 @@
 package hello
 
 import (
+	"civ/internal/pkg/logger"
 	context "context"
-	"log"
+	"log/slog"
 	"time"
 
 	grpc "google.golang.org/grpc"
@@ -12,19 +13,18 @@ import (
 func demo() {
 	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		logger.Fatal("did not connect", "error", err)
 	}
 	defer conn.Close()
 
-	c := pb.NewGreeterClient(conn)
+	c := NewGreeterClient(conn)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	r, err := c.SayHello(ctx, &pb.HelloRequest{Name: "Go Client", Age: 30})
+	r, err := c.SayHello(ctx, &HelloRequest{Name: "Go Client", Age: 30})
 	if err != nil {
-		log.Fatalf("could not greet: %v", err)
+		logger.Fatal("could not greet", "error", err)
 	}
-	log.Printf("Response: %s", r.Message)
-	log.Printf("Original age: 30, Server processed age: %d", r.Age)
+	slog.Info("greeter response", "message", r.Message, "age", r.Age)
 }
@@ -0,0 +1,13 @@
+#!/usr/bin/env sh
+# 同时生成 Go 与 Python 代码，避免两端的生成代码各自漂移。在项目根目录执行：sh proto/generate.sh
+set -e
+
+PROTOS="hello.proto analyzer/v1/analyzer.proto analyzer/v1/registry.proto analyzer/v1/tools.proto"
+PY_OUT=agent/src/grpc/proto
+
+protoc -I proto \
+       --go_out=backend/proto --go_opt=paths=source_relative \
+       --go-grpc_out=backend/proto --go-grpc_opt=paths=source_relative \
+       $PROTOS
+
+python -m grpc_tools.protoc -I proto --python_out=$PY_OUT --grpc_python_out=$PY_OUT $PROTOS